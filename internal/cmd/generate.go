@@ -3,6 +3,7 @@ package cmd
 import (
 	"flag"
 	"fmt"
+	"runtime"
 
 	"github.com/hashicorp/terraform-plugin-docs/internal/provider"
 )
@@ -11,7 +12,21 @@ type generateCmd struct {
 	commonCmd
 
 	flagLegacySidebar bool
-	tfVersion         string
+
+	flagProviderName        string
+	flagProviderSource      string
+	flagProviderDir         string
+	flagRenderedWebsiteDir  string
+	flagExamplesDir         string
+	flagWebsiteTmpDir       string
+	flagWebsiteSourceDir    string
+	flagProvidersSchemaPath string
+	flagConfigPath          string
+	flagWatch               bool
+	flagCheck               bool
+	flagInitManifest        bool
+	flagParallelism         int
+	tfVersion               string
 }
 
 func (cmd *generateCmd) Synopsis() string {
@@ -25,7 +40,20 @@ func (cmd *generateCmd) Help() string {
 func (cmd *generateCmd) Flags() *flag.FlagSet {
 	fs := flag.NewFlagSet("generate", flag.ExitOnError)
 	fs.BoolVar(&cmd.flagLegacySidebar, "legacy-sidebar", false, "generate the legacy .erb sidebar file")
+	fs.StringVar(&cmd.flagProviderName, "provider-name", "", "provider name, as used in Terraform configurations; defaults to the provider directory name")
+	fs.StringVar(&cmd.flagProviderSource, "provider-source", "", "provider source address in the form [<hostname>/]<namespace>/<type>, as used in required_providers (default \"registry.terraform.io/hashicorp/<provider short name>\")")
+	fs.StringVar(&cmd.flagProviderDir, "provider-dir", "", "relative or absolute path to the root provider code directory; defaults to the current working directory")
+	fs.StringVar(&cmd.flagRenderedWebsiteDir, "rendered-website-dir", "", "output directory, relative to the provider directory unless absolute (default \"docs\")")
+	fs.StringVar(&cmd.flagExamplesDir, "examples-dir", "", "examples directory, relative to the provider directory unless absolute (default \"examples\")")
+	fs.StringVar(&cmd.flagWebsiteTmpDir, "website-temp-dir", "", "temporary directory used during rendering; defaults to a new temporary directory that is removed afterwards")
+	fs.StringVar(&cmd.flagWebsiteSourceDir, "website-source-dir", "", "templates directory, relative to the provider directory unless absolute (default \"templates\")")
 	fs.StringVar(&cmd.tfVersion, "tf-version", "", "terraform binary version to download")
+	fs.StringVar(&cmd.flagProvidersSchemaPath, "providers-schema", "", "path to a providers schema JSON file, relative to the provider directory unless absolute, as output by terraform providers schema -json, to use instead of building the provider and exporting the schema")
+	fs.StringVar(&cmd.flagConfigPath, "config", "", "path to the configuration file, relative to the provider directory unless absolute (default \".tfplugindocs.hcl\")")
+	fs.BoolVar(&cmd.flagWatch, "watch", false, "watch templates, examples and provider sources and re-render affected docs on change")
+	fs.BoolVar(&cmd.flagCheck, "check", false, "render to a temporary directory and print a diff of the files that differ from the rendered website directory, failing if any file differs, without modifying it")
+	fs.BoolVar(&cmd.flagInitManifest, "init-manifest", false, "if the rendered website directory has no manifest yet, remove the existing files that are not generated, including files such as images that are not managed by tfplugindocs")
+	fs.IntVar(&cmd.flagParallelism, "parallelism", runtime.NumCPU(), "maximum number of files rendered in parallel")
 	return fs
 }
 
@@ -41,7 +69,24 @@ func (cmd *generateCmd) Run(args []string) int {
 }
 
 func (cmd *generateCmd) runInternal() error {
-	err := provider.Generate(cmd.ui, cmd.flagLegacySidebar, cmd.tfVersion)
+	err := provider.Generate(cmd.ui, provider.GenerateOptions{
+		ProviderDir:         cmd.flagProviderDir,
+		ProviderName:        cmd.flagProviderName,
+		ProviderSource:      cmd.flagProviderSource,
+		RenderedWebsiteDir:  cmd.flagRenderedWebsiteDir,
+		ExamplesDir:         cmd.flagExamplesDir,
+		WebsiteTmpDir:       cmd.flagWebsiteTmpDir,
+		WebsiteSourceDir:    cmd.flagWebsiteSourceDir,
+		TFVersion:           cmd.tfVersion,
+		ProvidersSchemaPath: cmd.flagProvidersSchemaPath,
+		ConfigPath:          cmd.flagConfigPath,
+
+		LegacySidebar: cmd.flagLegacySidebar,
+		Watch:         cmd.flagWatch,
+		Check:         cmd.flagCheck,
+		InitManifest:  cmd.flagInitManifest,
+		Parallelism:   cmd.flagParallelism,
+	})
 	if err != nil {
 		return fmt.Errorf("unable to generate website: %w", err)
 	}
@@ -1,3 +1,56 @@
 package cmd
 
-//TODO: this command can run an emulated server to simulate the registry
+import (
+	"flag"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-docs/internal/provider"
+)
+
+type serveCmd struct {
+	commonCmd
+
+	flagAddress            string
+	flagProviderName       string
+	flagProviderDir        string
+	flagRenderedWebsiteDir string
+	flagConfigPath         string
+}
+
+func (cmd *serveCmd) Synopsis() string {
+	return "serves the rendered plugin website for the current directory as a local registry preview"
+}
+
+func (cmd *serveCmd) Help() string {
+	return `Usage: tfplugindocs serve`
+}
+
+func (cmd *serveCmd) Flags() *flag.FlagSet {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	fs.StringVar(&cmd.flagAddress, "address", "localhost:8080", "address the preview server listens on")
+	fs.StringVar(&cmd.flagProviderName, "provider-name", "", "provider name, as used in Terraform configurations; defaults to the provider directory name")
+	fs.StringVar(&cmd.flagProviderDir, "provider-dir", "", "relative or absolute path to the root provider code directory; defaults to the current working directory")
+	fs.StringVar(&cmd.flagRenderedWebsiteDir, "rendered-website-dir", "", "rendered docs directory, relative to the provider directory unless absolute (default \"docs\")")
+	fs.StringVar(&cmd.flagConfigPath, "config", "", "path to the configuration file, relative to the provider directory unless absolute (default \".tfplugindocs.hcl\")")
+	return fs
+}
+
+func (cmd *serveCmd) Run(args []string) int {
+	fs := cmd.Flags()
+	err := fs.Parse(args)
+	if err != nil {
+		cmd.ui.Error(fmt.Sprintf("unable to parse flags: %s", err))
+		return 1
+	}
+
+	return cmd.run(cmd.runInternal)
+}
+
+func (cmd *serveCmd) runInternal() error {
+	err := provider.Serve(cmd.ui, cmd.flagProviderDir, cmd.flagProviderName, cmd.flagRenderedWebsiteDir, cmd.flagConfigPath, cmd.flagAddress)
+	if err != nil {
+		return fmt.Errorf("unable to serve website: %w", err)
+	}
+
+	return nil
+}
@@ -9,6 +9,17 @@ import (
 
 type validateCmd struct {
 	commonCmd
+
+	flagProviderName        string
+	flagProviderSource      string
+	flagProviderDir         string
+	flagRenderedWebsiteDir  string
+	flagExamplesDir         string
+	flagWebsiteSourceDir    string
+	flagProvidersSchemaPath string
+	flagTFVersion           string
+	flagConfigPath          string
+	flagCheckSchema         bool
 }
 
 func (cmd *validateCmd) Synopsis() string {
@@ -21,6 +32,16 @@ func (cmd *validateCmd) Help() string {
 
 func (cmd *validateCmd) Flags() *flag.FlagSet {
 	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	fs.StringVar(&cmd.flagProviderName, "provider-name", "", "provider name, as used in Terraform configurations; defaults to the provider directory name")
+	fs.StringVar(&cmd.flagProviderSource, "provider-source", "", "provider source address in the form [<hostname>/]<namespace>/<type>, used to find the provider in the providers schema (default \"registry.terraform.io/hashicorp/<provider short name>\")")
+	fs.StringVar(&cmd.flagProviderDir, "provider-dir", "", "relative or absolute path to the root provider code directory; defaults to the current working directory")
+	fs.StringVar(&cmd.flagRenderedWebsiteDir, "rendered-website-dir", "", "rendered docs directory, relative to the provider directory unless absolute (default \"docs\")")
+	fs.StringVar(&cmd.flagExamplesDir, "examples-dir", "", "examples directory, relative to the provider directory unless absolute (default \"examples\")")
+	fs.StringVar(&cmd.flagWebsiteSourceDir, "website-source-dir", "", "templates directory, relative to the provider directory unless absolute (default \"templates\")")
+	fs.StringVar(&cmd.flagProvidersSchemaPath, "providers-schema", "", "path to a providers schema JSON file, relative to the provider directory unless absolute, as output by terraform providers schema -json, to validate examples and rendered docs against instead of building the provider and exporting the schema")
+	fs.StringVar(&cmd.flagTFVersion, "tf-version", "", "terraform binary version to download when exporting the schema")
+	fs.StringVar(&cmd.flagConfigPath, "config", "", "path to the configuration file, relative to the provider directory unless absolute (default \".tfplugindocs.hcl\")")
+	fs.BoolVar(&cmd.flagCheckSchema, "check-schema", false, "check the rendered docs against the provider schema, exporting the schema like generate unless -providers-schema is set")
 	return fs
 }
 
@@ -36,7 +57,19 @@ func (cmd *validateCmd) Run(args []string) int {
 }
 
 func (cmd *validateCmd) runInternal() error {
-	err := provider.Validate(cmd.ui)
+	err := provider.Validate(cmd.ui, provider.ValidateOptions{
+		ProviderDir:         cmd.flagProviderDir,
+		ProviderName:        cmd.flagProviderName,
+		ProviderSource:      cmd.flagProviderSource,
+		RenderedWebsiteDir:  cmd.flagRenderedWebsiteDir,
+		ExamplesDir:         cmd.flagExamplesDir,
+		WebsiteSourceDir:    cmd.flagWebsiteSourceDir,
+		TFVersion:           cmd.flagTFVersion,
+		ProvidersSchemaPath: cmd.flagProvidersSchemaPath,
+		ConfigPath:          cmd.flagConfigPath,
+
+		CheckSchema: cmd.flagCheckSchema,
+	})
 	if err != nil {
 		return fmt.Errorf("unable to validate website: %w", err)
 	}
@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// renderedDocsDir is the directory the website is rendered to, which is a
+// scratch dir in the tmp dir when checking the provider docs dir for drift.
+func (g *generator) renderedDocsDir() string {
+	if g.check {
+		return filepath.Join(g.websiteTmpDir, "docs")
+	}
+	return g.providerDocsDir()
+}
+
+// renderedLegacySidebarPath is the path the legacy sidebar is rendered to.
+func (g *generator) renderedLegacySidebarPath() string {
+	shortName := providerShortName(g.providerName)
+	if g.check {
+		return legacySidebarPath(g.websiteTmpDir, shortName)
+	}
+	return legacySidebarPath(g.providerDir, shortName)
+}
+
+// checkRendered compares the website rendered to the scratch dir with the
+// provider docs dir, printing a unified diff for every file that differs, and
+// returns an error if any file differs.
+func (g *generator) checkRendered() error {
+	// only files generated by tfplugindocs are compared, so other files such
+	// as images are not reported as removed. Without a manifest stale pages
+	// cannot be told apart from other files.
+	committed, hasManifest, err := readManifest(g.providerDocsDir())
+	if err != nil {
+		return err
+	}
+	if !hasManifest {
+		return fmt.Errorf("no %s found in the rendered website directory, run tfplugindocs generate to create it before checking", manifestFileName)
+	}
+	committed[manifestFileName] = true
+	rendered, err := listFiles(g.renderedDocsDir())
+	if err != nil {
+		return err
+	}
+
+	rels := []string{}
+	for rel := range committed {
+		rels = append(rels, rel)
+	}
+	for rel := range rendered {
+		if !committed[rel] {
+			rels = append(rels, rel)
+		}
+	}
+	sort.Strings(rels)
+
+	changed := 0
+	for _, rel := range rels {
+		differs, err := g.compareRenderedFile(
+			filepath.Join(g.providerDocsDir(), filepath.FromSlash(rel)),
+			filepath.Join(g.renderedDocsDir(), filepath.FromSlash(rel)),
+		)
+		if err != nil {
+			return err
+		}
+		if differs {
+			changed++
+		}
+	}
+
+	if g.legacySidebar {
+		differs, err := g.compareRenderedFile(
+			legacySidebarPath(g.providerDir, providerShortName(g.providerName)),
+			g.renderedLegacySidebarPath(),
+		)
+		if err != nil {
+			return err
+		}
+		if differs {
+			changed++
+		}
+	}
+
+	if changed > 0 {
+		return fmt.Errorf("rendered website is out of date, %d file(s) differ, run tfplugindocs generate to update it", changed)
+	}
+
+	g.infof("rendered website is up to date")
+	return nil
+}
+
+// compareRenderedFile prints the diff between the committed and rendered file,
+// either of which may not exist, and returns true if they differ.
+func (g *generator) compareRenderedFile(committedPath, renderedPath string) (bool, error) {
+	readFile := func(path string) ([]byte, bool, error) {
+		data, err := ioutil.ReadFile(path)
+		switch {
+		case os.IsNotExist(err):
+			return nil, false, nil
+		case err != nil:
+			return nil, false, err
+		}
+		return data, true, nil
+	}
+
+	committed, committedExists, err := readFile(committedPath)
+	if err != nil {
+		return false, err
+	}
+	rendered, renderedExists, err := readFile(renderedPath)
+	if err != nil {
+		return false, err
+	}
+
+	if committedExists == renderedExists && bytes.Equal(committed, rendered) {
+		return false, nil
+	}
+
+	name := committedPath
+	if rel, err := filepath.Rel(g.providerDir, committedPath); err == nil {
+		name = filepath.ToSlash(rel)
+	}
+
+	fromName, toName := "a/"+name, "b/"+name
+	if !committedExists {
+		fromName = "/dev/null"
+	}
+	if !renderedExists {
+		toName = "/dev/null"
+	}
+
+	if bytes.IndexByte(committed, 0) != -1 || bytes.IndexByte(rendered, 0) != -1 {
+		g.ui.Output(fmt.Sprintf("Binary files %s and %s differ", fromName, toName))
+		return true, nil
+	}
+
+	diff, err := unifiedDiff(fromName, toName, string(committed), string(rendered))
+	if err != nil {
+		return false, fmt.Errorf("unable to diff %q: %w", name, err)
+	}
+	g.ui.Output(diff)
+	return true, nil
+}
+
+// listFiles returns the set of files in dir as slash separated paths relative
+// to dir. A missing dir is treated as empty.
+func listFiles(dir string) (map[string]bool, error) {
+	files := map[string]bool{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == dir {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
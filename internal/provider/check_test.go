@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCheckRendered(t *testing.T) {
+	writeFiles := func(t *testing.T, dir string, files map[string]string) {
+		t.Helper()
+
+		for rel, content := range files {
+			path := filepath.Join(dir, filepath.FromSlash(rel))
+			err := os.MkdirAll(filepath.Dir(path), 0755)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			err = ioutil.WriteFile(path, []byte(content), 0644)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		}
+	}
+
+	for _, c := range []struct {
+		name           string
+		committed      map[string]string
+		rendered       map[string]string
+		expectedOutput []string
+		expectedErr    string
+	}{
+		{
+			"up to date",
+			map[string]string{
+				"docs/" + manifestFileName: manifestHeader + "index.md\n",
+				"docs/index.md":            "# Example\n",
+				"docs/images/logo.png":     "\x89PNG\x00",
+			},
+			map[string]string{
+				"docs/" + manifestFileName: manifestHeader + "index.md\n",
+				"docs/index.md":            "# Example\n",
+			},
+			nil,
+			"",
+		},
+		{
+			"changed and added pages",
+			map[string]string{
+				"docs/" + manifestFileName: manifestHeader + "index.md\n",
+				"docs/index.md":            "# Example\n",
+				"docs/images/logo.png":     "\x89PNG\x00",
+			},
+			map[string]string{
+				"docs/" + manifestFileName: manifestHeader + "index.md\n",
+				"docs/index.md":            "# Example Provider\n",
+				"docs/resources/thing.md":  "# example_thing\n",
+			},
+			[]string{
+				"--- a/docs/index.md\n+++ b/docs/index.md\n@@ -1 +1 @@\n-# Example\n+# Example Provider\n",
+				"--- /dev/null\n+++ b/docs/resources/thing.md\n@@ -0,0 +1 @@\n+# example_thing\n",
+			},
+			"rendered website is out of date, 2 file(s) differ, run tfplugindocs generate to update it",
+		},
+		{
+			"no manifest",
+			map[string]string{
+				"docs/index.md":          "# Example\n",
+				"docs/resources/gone.md": "# example_gone\n",
+			},
+			map[string]string{
+				"docs/" + manifestFileName: manifestHeader + "index.md\n",
+				"docs/index.md":            "# Example\n",
+			},
+			nil,
+			"no .tfplugindocs-manifest found in the rendered website directory, run tfplugindocs generate to create it before checking",
+		},
+		{
+			"removed page listed in manifest",
+			map[string]string{
+				"docs/" + manifestFileName: manifestHeader + "index.md\nresources/gone.md\n",
+				"docs/index.md":            "# Example\n",
+				"docs/resources/gone.md":   "# example_gone\n",
+				"docs/images/logo.png":     "\x89PNG\x00",
+			},
+			map[string]string{
+				"docs/" + manifestFileName: manifestHeader + "index.md\n",
+				"docs/index.md":            "# Example\n",
+			},
+			[]string{
+				"--- a/docs/" + manifestFileName + "\n+++ b/docs/" + manifestFileName + "\n@@ -1,4 +1,3 @@\n # generated by https://github.com/hashicorp/terraform-plugin-docs\n # files in this directory managed by tfplugindocs, other files are left untouched\n index.md\n-resources/gone.md\n",
+				"--- a/docs/resources/gone.md\n+++ /dev/null\n@@ -1 +0,0 @@\n-# example_gone\n",
+			},
+			"rendered website is out of date, 2 file(s) differ, run tfplugindocs generate to update it",
+		},
+		{
+			"binary file",
+			map[string]string{
+				"docs/" + manifestFileName: manifestHeader + "images/logo.png\n",
+				"docs/images/logo.png":     "\x89PNG\x00",
+			},
+			map[string]string{
+				"docs/" + manifestFileName: manifestHeader + "images/logo.png\n",
+				"docs/images/logo.png":     "\x89PNG\x00\x01",
+			},
+			[]string{
+				"Binary files a/docs/images/logo.png and b/docs/images/logo.png differ",
+			},
+			"rendered website is out of date, 1 file(s) differ, run tfplugindocs generate to update it",
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			providerDir := t.TempDir()
+			websiteTmpDir := t.TempDir()
+			writeFiles(t, providerDir, c.committed)
+			writeFiles(t, websiteTmpDir, c.rendered)
+
+			ui := &bufferedUi{}
+			g := &generator{
+				providerDir:        providerDir,
+				providerName:       "terraform-provider-example",
+				renderedWebsiteDir: "docs",
+				websiteTmpDir:      websiteTmpDir,
+				check:              true,
+				ui:                 ui,
+			}
+			if g.renderedDocsDir() != filepath.Join(websiteTmpDir, "docs") {
+				t.Fatalf("expected to render to the tmp dir, got %q", g.renderedDocsDir())
+			}
+
+			err := g.checkRendered()
+			switch {
+			case c.expectedErr == "" && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case c.expectedErr != "" && err == nil:
+				t.Fatalf("expected error %q, got none", c.expectedErr)
+			case c.expectedErr != "" && err.Error() != c.expectedErr:
+				t.Fatalf("expected error %q, got %q", c.expectedErr, err)
+			}
+
+			var output []string
+			for _, m := range ui.messages {
+				if m.level == "output" {
+					output = append(output, m.message)
+				}
+			}
+			if diff := cmp.Diff(c.expectedOutput, output); diff != "" {
+				t.Fatalf("Unexpected output (-wanted, +got): %s", diff)
+			}
+		})
+	}
+}
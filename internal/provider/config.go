@@ -0,0 +1,326 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
+
+	"github.com/hashicorp/terraform-plugin-docs/schemamd"
+)
+
+// configFileName is the name of the project configuration file in the root
+// provider directory.
+const configFileName = ".tfplugindocs.hcl"
+
+// config is the project configuration shared by generate and validate, for
+// example:
+//
+//	provider_name        = "terraform-provider-example"
+//	provider_source      = "example.com/examplecorp/example"
+//	rendered_website_dir = "docs"
+//
+//	paths {
+//	  website_resource_file = "resources/{{ .ShortName }}.md.tmpl"
+//	}
+//
+//	schema {
+//	  style                        = "classic"
+//	  inline_object_max_attributes = 3
+//	  data_source_groups           = ["required", "optional"]
+//
+//	  group "read-only" {
+//	    title = "Attributes"
+//	  }
+//	}
+//
+//	validate {
+//	  checks = ["allowed_files", "allowed_dirs"]
+//	}
+//
+//	resource "example_thing" {
+//	  subcategory = "Things"
+//	}
+//
+// Flags take precedence over values set in the file.
+type config struct {
+	ProviderName       string `hcl:"provider_name,optional"`
+	ProviderSource     string `hcl:"provider_source,optional"`
+	RenderedWebsiteDir string `hcl:"rendered_website_dir,optional"`
+	ExamplesDir        string `hcl:"examples_dir,optional"`
+	WebsiteSourceDir   string `hcl:"website_source_dir,optional"`
+	WebsiteTmpDir      string `hcl:"website_temp_dir,optional"`
+
+	Paths    *pathsConfig    `hcl:"paths,block"`
+	Schema   *schemaConfig   `hcl:"schema,block"`
+	Validate *validateConfig `hcl:"validate,block"`
+
+	Resources   []resourceConfig `hcl:"resource,block"`
+	DataSources []resourceConfig `hcl:"data_source,block"`
+}
+
+type pathsConfig struct {
+	ExamplesResourceFile   string `hcl:"examples_resource_file,optional"`
+	ExamplesResourceImport string `hcl:"examples_resource_import,optional"`
+	ExamplesDataSourceFile string `hcl:"examples_data_source_file,optional"`
+	ExamplesProviderFile   string `hcl:"examples_provider_file,optional"`
+
+	WebsiteResourceFile           string `hcl:"website_resource_file,optional"`
+	WebsiteResourceFallbackFile   string `hcl:"website_resource_fallback_file,optional"`
+	WebsiteDataSourceFile         string `hcl:"website_data_source_file,optional"`
+	WebsiteDataSourceFallbackFile string `hcl:"website_data_source_fallback_file,optional"`
+	WebsiteProviderFile           string `hcl:"website_provider_file,optional"`
+}
+
+type schemaConfig struct {
+	// Style is the layout of rendered schemas, see schemamd.Style.
+	Style string `hcl:"style,optional"`
+
+	InlineObjectMaxAttributes int `hcl:"inline_object_max_attributes,optional"`
+
+	HeadingLevel int `hcl:"heading_level,optional"`
+
+	EscapePlainDescriptions bool `hcl:"escape_plain_descriptions,optional"`
+
+	NestedSummaries bool `hcl:"nested_summaries,optional"`
+
+	// Groups are the groups written, in order, see schemamd.Options.Groups.
+	Groups []string `hcl:"groups,optional"`
+	// DataSourceGroups override Groups for data sources.
+	DataSourceGroups []string `hcl:"data_source_groups,optional"`
+
+	GroupTitles []groupTitleConfig `hcl:"group,block"`
+}
+
+// groupTitleConfig overrides the titles of a schema group.
+type groupTitleConfig struct {
+	Group       string `hcl:"group,label"`
+	Title       string `hcl:"title,optional"`
+	NestedTitle string `hcl:"nested_title,optional"`
+}
+
+type validateConfig struct {
+	// Checks is the list of enabled checks, all checks are enabled if unset.
+	Checks *[]string `hcl:"checks,optional"`
+}
+
+// resourceConfig holds per resource or data source overrides.
+type resourceConfig struct {
+	Name        string `hcl:"name,label"`
+	Subcategory string `hcl:"subcategory,optional"`
+}
+
+// loadConfig reads the configuration file at path, relative to the provider
+// directory unless absolute. If path is empty the configuration file in the
+// provider directory is read, and an empty configuration is returned if it does
+// not exist.
+func loadConfig(providerDir, path string) (*config, error) {
+	explicit := path != ""
+	if !explicit {
+		path = configFileName
+	}
+	path = resolvePath(providerDir, path)
+
+	_, err := os.Stat(path)
+	switch {
+	case os.IsNotExist(err) && !explicit:
+		return &config{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("unable to read config file: %w", err)
+	}
+
+	parser := hclparse.NewParser()
+	f, diags := parser.ParseHCLFile(path)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("unable to parse config file %q: %w", path, diags)
+	}
+
+	var cfg config
+	diags = gohcl.DecodeBody(f.Body, nil, &cfg)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("unable to decode config file %q: %w", path, diags)
+	}
+
+	err = cfg.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file %q: %w", path, err)
+	}
+
+	return &cfg, nil
+}
+
+func (cfg *config) validate() error {
+	if cfg.Schema != nil {
+		if cfg.Schema.InlineObjectMaxAttributes < 0 {
+			return fmt.Errorf("inline_object_max_attributes must not be negative")
+		}
+
+		seen := map[string]bool{}
+		for _, g := range cfg.Schema.GroupTitles {
+			if seen[g.Group] {
+				return fmt.Errorf("duplicate schema group block for %q", g.Group)
+			}
+			seen[g.Group] = true
+		}
+
+		err := cfg.schemaOptions().Validate()
+		if err != nil {
+			return err
+		}
+
+		err = cfg.dataSourceSchemaOptions().Validate()
+		if err != nil {
+			return fmt.Errorf("invalid data_source_groups: %w", err)
+		}
+	}
+
+	if cfg.Validate != nil && cfg.Validate.Checks != nil {
+		for _, name := range *cfg.Validate.Checks {
+			if !knownChecks[name] {
+				return fmt.Errorf("unknown validation check %q", name)
+			}
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, r := range cfg.Resources {
+		if seen[r.Name] {
+			return fmt.Errorf("duplicate resource block for %q", r.Name)
+		}
+		seen[r.Name] = true
+	}
+
+	seen = map[string]bool{}
+	for _, r := range cfg.DataSources {
+		if seen[r.Name] {
+			return fmt.Errorf("duplicate data_source block for %q", r.Name)
+		}
+		seen[r.Name] = true
+	}
+
+	return nil
+}
+
+// pathTemplates returns the default path templates with any overrides from the
+// configuration applied.
+func (cfg *config) pathTemplates() pathTemplates {
+	paths := defaultPathTemplates()
+	if cfg.Paths == nil {
+		return paths
+	}
+
+	setResource := func(dst *resourceFileTemplate, v string) {
+		if v != "" {
+			*dst = resourceFileTemplate(v)
+		}
+	}
+	setProvider := func(dst *providerFileTemplate, v string) {
+		if v != "" {
+			*dst = providerFileTemplate(v)
+		}
+	}
+
+	setResource(&paths.examplesResourceFile, cfg.Paths.ExamplesResourceFile)
+	setResource(&paths.examplesResourceImport, cfg.Paths.ExamplesResourceImport)
+	setResource(&paths.examplesDataSourceFile, cfg.Paths.ExamplesDataSourceFile)
+	setProvider(&paths.examplesProviderFile, cfg.Paths.ExamplesProviderFile)
+
+	setResource(&paths.websiteResourceFile, cfg.Paths.WebsiteResourceFile)
+	setResource(&paths.websiteResourceFallbackFile, cfg.Paths.WebsiteResourceFallbackFile)
+	setResource(&paths.websiteDataSourceFile, cfg.Paths.WebsiteDataSourceFile)
+	setResource(&paths.websiteDataSourceFallbackFile, cfg.Paths.WebsiteDataSourceFallbackFile)
+	setProvider(&paths.websiteProviderFile, cfg.Paths.WebsiteProviderFile)
+
+	return paths
+}
+
+// schemaOptions returns the options used to render schemas.
+func (cfg *config) schemaOptions() schemamd.Options {
+	if cfg.Schema == nil {
+		return schemamd.Options{}
+	}
+
+	opts := schemamd.Options{
+		Style:                     schemamd.Style(cfg.Schema.Style),
+		InlineObjectMaxAttributes: cfg.Schema.InlineObjectMaxAttributes,
+		HeadingLevel:              cfg.Schema.HeadingLevel,
+		Groups:                    schemaGroups(cfg.Schema.Groups),
+		EscapePlainDescriptions:   cfg.Schema.EscapePlainDescriptions,
+		NestedSummaries:           cfg.Schema.NestedSummaries,
+	}
+
+	if len(cfg.Schema.GroupTitles) > 0 {
+		opts.GroupTitles = map[schemamd.Group]schemamd.GroupTitle{}
+		for _, g := range cfg.Schema.GroupTitles {
+			opts.GroupTitles[schemamd.Group(g.Group)] = schemamd.GroupTitle{
+				Title:       g.Title,
+				NestedTitle: g.NestedTitle,
+			}
+		}
+	}
+
+	return opts
+}
+
+// dataSourceSchemaOptions returns the options used to render data source
+// schemas.
+func (cfg *config) dataSourceSchemaOptions() schemamd.Options {
+	opts := cfg.schemaOptions()
+	if cfg.Schema != nil && cfg.Schema.DataSourceGroups != nil {
+		opts.Groups = schemaGroups(cfg.Schema.DataSourceGroups)
+	}
+	return opts
+}
+
+func schemaGroups(names []string) []schemamd.Group {
+	groups := []schemamd.Group{}
+	for _, name := range names {
+		groups = append(groups, schemamd.Group(name))
+	}
+	return groups
+}
+
+// enabledChecks returns the set of enabled validation checks.
+func (cfg *config) enabledChecks() map[string]bool {
+	enabled := map[string]bool{}
+	if cfg.Validate == nil || cfg.Validate.Checks == nil {
+		for name := range knownChecks {
+			enabled[name] = true
+		}
+		return enabled
+	}
+
+	for _, name := range *cfg.Validate.Checks {
+		enabled[name] = true
+	}
+	return enabled
+}
+
+func (cfg *config) resourceSubcategory(name string) string {
+	for _, r := range cfg.Resources {
+		if r.Name == name {
+			return r.Subcategory
+		}
+	}
+	return ""
+}
+
+func (cfg *config) dataSourceSubcategory(name string) string {
+	for _, r := range cfg.DataSources {
+		if r.Name == name {
+			return r.Subcategory
+		}
+	}
+	return ""
+}
+
+// stringOrDefault returns the first non-empty value.
+func stringOrDefault(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-docs/schemamd"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	err := ioutil.WriteFile(filepath.Join(dir, configFileName), []byte(content), 0644)
+	if err != nil {
+		t.Fatalf("unable to write config: %s", err)
+	}
+	return dir
+}
+
+func TestLoadConfig(t *testing.T) {
+	cfg, err := loadConfig(t.TempDir(), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(defaultPathTemplates(), cfg.pathTemplates(), cmp.AllowUnexported(pathTemplates{})); diff != "" {
+		t.Fatalf("Unexpected default path templates (-wanted, +got): %s", diff)
+	}
+	if diff := cmp.Diff(knownChecks, cfg.enabledChecks()); diff != "" {
+		t.Fatalf("Unexpected default checks (-wanted, +got): %s", diff)
+	}
+
+	dir := writeConfig(t, `
+provider_name   = "terraform-provider-example"
+provider_source = "example.com/examplecorp/example"
+examples_dir    = "docs-examples"
+
+paths {
+  website_resource_file = "r/{{ .ShortName }}.html.md.tmpl"
+}
+
+schema {
+  style                        = "table"
+  inline_object_max_attributes = 2
+  heading_level                = 3
+  escape_plain_descriptions    = true
+  nested_summaries             = true
+  groups                       = ["optional", "required"]
+  data_source_groups           = ["read-only"]
+
+  group "read-only" {
+    title        = "Attributes"
+    nested_title = "Attributes:"
+  }
+}
+
+validate {
+  checks = ["allowed_files"]
+}
+
+resource "example_thing" {
+  subcategory = "Things"
+}
+
+data_source "example_thing" {
+  subcategory = "Thing Lookups"
+}
+`)
+
+	cfg, err = loadConfig(dir, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if cfg.ProviderName != "terraform-provider-example" || cfg.ProviderSource != "example.com/examplecorp/example" || cfg.ExamplesDir != "docs-examples" {
+		t.Fatalf("unexpected top level values: %+v", cfg)
+	}
+
+	paths := cfg.pathTemplates()
+	if paths.websiteResourceFile != "r/{{ .ShortName }}.html.md.tmpl" {
+		t.Fatalf("expected website_resource_file override, got %q", paths.websiteResourceFile)
+	}
+	if paths.websiteDataSourceFile != defaultPathTemplates().websiteDataSourceFile {
+		t.Fatalf("expected default website_data_source_file, got %q", paths.websiteDataSourceFile)
+	}
+
+	titles := map[schemamd.Group]schemamd.GroupTitle{
+		schemamd.GroupReadOnly: {Title: "Attributes", NestedTitle: "Attributes:"},
+	}
+	expected := schemamd.Options{
+		Style:                     schemamd.StyleTable,
+		InlineObjectMaxAttributes: 2,
+		HeadingLevel:              3,
+		Groups:                    []schemamd.Group{schemamd.GroupOptional, schemamd.GroupRequired},
+		EscapePlainDescriptions:   true,
+		NestedSummaries:           true,
+		GroupTitles:               titles,
+	}
+	if diff := cmp.Diff(expected, cfg.schemaOptions()); diff != "" {
+		t.Fatalf("Unexpected schema options (-wanted, +got): %s", diff)
+	}
+	expected.Groups = []schemamd.Group{schemamd.GroupReadOnly}
+	if diff := cmp.Diff(expected, cfg.dataSourceSchemaOptions()); diff != "" {
+		t.Fatalf("Unexpected data source schema options (-wanted, +got): %s", diff)
+	}
+
+	if diff := cmp.Diff(map[string]bool{"allowed_files": true}, cfg.enabledChecks()); diff != "" {
+		t.Fatalf("Unexpected checks (-wanted, +got): %s", diff)
+	}
+
+	if cfg.resourceSubcategory("example_thing") != "Things" || cfg.dataSourceSubcategory("example_thing") != "Thing Lookups" || cfg.resourceSubcategory("example_other") != "" {
+		t.Fatalf("unexpected subcategories")
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	for _, c := range []struct {
+		name        string
+		content     string
+		expectedErr string
+	}{
+		{
+			"syntax error",
+			`provider_name = `,
+			"unable to parse config file",
+		},
+		{
+			"unknown argument",
+			`provider_nam = "terraform-provider-example"`,
+			`.tfplugindocs.hcl:1,1-13: Unsupported argument; An argument named "provider_nam" is not expected here.`,
+		},
+		{
+			"unknown nested argument",
+			`schema {
+  styl = "classic"
+}`,
+			`.tfplugindocs.hcl:2,3-7: Unsupported argument; An argument named "styl" is not expected here.`,
+		},
+		{
+			"unknown block",
+			`paths {}
+sidebar {}`,
+			`.tfplugindocs.hcl:2,1-8: Unsupported block type; Blocks of type "sidebar" are not expected here.`,
+		},
+		{
+			"negative inline object max attributes",
+			`schema { inline_object_max_attributes = -1 }`,
+			"inline_object_max_attributes must not be negative",
+		},
+		{
+			"unknown style",
+			`schema { style = "fancy" }`,
+			`unknown schema style "fancy"`,
+		},
+		{
+			"unknown group",
+			`schema { groups = ["computed"] }`,
+			`unknown schema group "computed"`,
+		},
+		{
+			"unknown data source group",
+			`schema { data_source_groups = ["computed"] }`,
+			`invalid data_source_groups: unknown schema group "computed"`,
+		},
+		{
+			"duplicate group block",
+			`schema {
+  group "required" {}
+  group "required" {}
+}`,
+			`duplicate schema group block for "required"`,
+		},
+		{
+			"unknown check",
+			`validate { checks = ["spelling"] }`,
+			`unknown validation check "spelling"`,
+		},
+		{
+			"duplicate resource block",
+			`resource "example_thing" {}
+resource "example_thing" {}`,
+			`duplicate resource block for "example_thing"`,
+		},
+		{
+			"duplicate data source block",
+			`data_source "example_thing" {}
+data_source "example_thing" {}`,
+			`duplicate data_source block for "example_thing"`,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := loadConfig(writeConfig(t, c.content), "")
+			if err == nil {
+				t.Fatalf("expected error %q, got none", c.expectedErr)
+			}
+			if !strings.Contains(err.Error(), c.expectedErr) {
+				t.Fatalf("expected error containing %q, got %q", c.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestLoadConfigPath(t *testing.T) {
+	dir := writeConfig(t, `provider_name = "terraform-provider-default"`)
+	err := ioutil.WriteFile(filepath.Join(dir, "docs.hcl"), []byte(`provider_name = "terraform-provider-other"`), 0644)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, c := range []struct {
+		name                 string
+		path                 string
+		expectedProviderName string
+		expectedErr          string
+	}{
+		{"default", "", "terraform-provider-default", ""},
+		{"relative", "docs.hcl", "terraform-provider-other", ""},
+		{"absolute", filepath.Join(dir, "docs.hcl"), "terraform-provider-other", ""},
+		// unlike the default file, a file passed explicitly must exist
+		{"missing", "missing.hcl", "", "unable to read config file"},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			cfg, err := loadConfig(dir, c.path)
+			switch {
+			case c.expectedErr == "" && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case c.expectedErr != "" && err == nil:
+				t.Fatalf("expected error %q, got none", c.expectedErr)
+			case c.expectedErr != "":
+				if !strings.Contains(err.Error(), c.expectedErr) {
+					t.Fatalf("expected error containing %q, got %q", c.expectedErr, err)
+				}
+				return
+			}
+
+			if cfg.ProviderName != c.expectedProviderName {
+				t.Fatalf("expected provider name %q, got %q", c.expectedProviderName, cfg.ProviderName)
+			}
+		})
+	}
+}
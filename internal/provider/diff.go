@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// diffContext is the number of unchanged lines shown around each change of a
+// unified diff.
+const diffContext = 3
+
+// noNewlineMarker follows the last line of a file without a trailing newline
+// in a unified diff.
+const noNewlineMarker = "\\ No newline at end of file"
+
+// splitLines splits content into lines with their line endings, as expected by
+// difflib. A last line without a trailing newline is followed by the no newline
+// marker, so it differs from the same line with a newline and the marker is
+// written after it in the diff.
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(content, "\n")
+	if last := len(lines) - 1; lines[last] == "" {
+		lines = lines[:last]
+	} else {
+		lines[last] += "\n" + noNewlineMarker + "\n"
+	}
+	return lines
+}
+
+// unifiedDiff returns the unified diff of the two contents, or an empty string
+// if they are equal.
+func unifiedDiff(fromName, toName, from, to string) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(from),
+		B:        splitLines(to),
+		FromFile: fromName,
+		ToFile:   toName,
+		Context:  diffContext,
+	})
+}
@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	alphabet := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\n"
+
+	for _, c := range []struct {
+		name     string
+		from     string
+		to       string
+		expected string
+	}{
+		{
+			"equal",
+			alphabet,
+			alphabet,
+			"",
+		},
+		{
+			"both empty",
+			"",
+			"",
+			"",
+		},
+		{
+			"two hunks",
+			alphabet,
+			strings.NewReplacer("d\n", "D\n", "l\n", "L\n").Replace(alphabet),
+			`--- a/file
++++ b/file
+@@ -1,7 +1,7 @@
+ a
+ b
+ c
+-d
++D
+ e
+ f
+ g
+@@ -9,5 +9,5 @@
+ i
+ j
+ k
+-l
++L
+ m
+`,
+		},
+		{
+			"changes within twice the context share a hunk",
+			alphabet,
+			strings.NewReplacer("d\n", "D\n", "j\n", "J\n").Replace(alphabet),
+			`--- a/file
++++ b/file
+@@ -1,13 +1,13 @@
+ a
+ b
+ c
+-d
++D
+ e
+ f
+ g
+ h
+ i
+-j
++J
+ k
+ l
+ m
+`,
+		},
+		{
+			"single change",
+			alphabet,
+			strings.Replace(alphabet, "i\n", "I\n", 1),
+			`--- a/file
++++ b/file
+@@ -6,7 +6,7 @@
+ f
+ g
+ h
+-i
++I
+ j
+ k
+ l
+`,
+		},
+		{
+			"added and removed lines",
+			"a\nb\nc\n",
+			"a\nx\ny\nc\n",
+			`--- a/file
++++ b/file
+@@ -1,3 +1,4 @@
+ a
+-b
++x
++y
+ c
+`,
+		},
+		{
+			"added file",
+			"",
+			"x\ny\n",
+			`--- a/file
++++ b/file
+@@ -0,0 +1,2 @@
++x
++y
+`,
+		},
+		{
+			"removed file",
+			"x\ny\n",
+			"",
+			`--- a/file
++++ b/file
+@@ -1,2 +0,0 @@
+-x
+-y
+`,
+		},
+		{
+			"added trailing newline",
+			"a\nb",
+			"a\nb\n",
+			`--- a/file
++++ b/file
+@@ -1,2 +1,2 @@
+ a
+-b
+\ No newline at end of file
++b
+`,
+		},
+		{
+			"removed trailing newline",
+			"a\nb\n",
+			"a\nb",
+			`--- a/file
++++ b/file
+@@ -1,2 +1,2 @@
+ a
+-b
++b
+\ No newline at end of file
+`,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := unifiedDiff("a/file", "b/file", c.from, c.to)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(c.expected, actual); diff != "" {
+				t.Fatalf("Unexpected diff (-wanted, +got): %s", diff)
+			}
+		})
+	}
+}
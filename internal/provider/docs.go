@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// docsCategories are the subdirectories of the rendered website directory in
+// the order the Terraform Registry displays them in the navigation.
+var docsCategories = []docsCategory{
+	{dir: "guides", title: "Guides"},
+	{dir: "resources", title: "Resources"},
+	{dir: "data-sources", title: "Data Sources"},
+}
+
+type docsCategory struct {
+	dir   string
+	title string
+}
+
+// docsPage is a single Markdown file of the rendered website.
+type docsPage struct {
+	// category is the subdirectory of the page, empty for the index page
+	category string
+	name     string
+	file     string
+
+	title       string
+	subcategory string
+	description string
+}
+
+func (p *docsPage) URL() string {
+	if p.category == "" {
+		return "/docs"
+	}
+	return path.Join("/docs", p.category, p.name)
+}
+
+// NavTitle is the title of the page in the navigation, the registry uses the
+// full resource name for resources and data sources.
+func (p *docsPage) NavTitle(providerShortName string) string {
+	switch p.category {
+	case "resources", "data-sources":
+		return providerShortName + "_" + p.name
+	}
+	if p.title != "" {
+		return p.title
+	}
+	return p.name
+}
+
+// loadDocsPages reads the frontmatter of the index page and all pages of the
+// docs categories in the rendered website directory.
+func loadDocsPages(docsDir string) ([]*docsPage, error) {
+	pages := []*docsPage{}
+
+	indexPath := filepath.Join(docsDir, "index.md")
+	if fileExists(indexPath) {
+		p, err := loadDocsPage(indexPath, "", "index")
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, p)
+	}
+
+	for _, c := range docsCategories {
+		dir := filepath.Join(docsDir, c.dir)
+		infos, err := ioutil.ReadDir(dir)
+		switch {
+		case os.IsNotExist(err):
+			continue
+		case err != nil:
+			return nil, err
+		}
+
+		for _, fi := range infos {
+			if fi.IsDir() || filepath.Ext(fi.Name()) != ".md" {
+				continue
+			}
+
+			p, err := loadDocsPage(filepath.Join(dir, fi.Name()), c.dir, removeAllExt(fi.Name()))
+			if err != nil {
+				return nil, err
+			}
+			pages = append(pages, p)
+		}
+	}
+
+	return pages, nil
+}
+
+func loadDocsPage(file, category, name string) (*docsPage, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	fm, _, err := splitFrontmatter(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse frontmatter of %q: %w", file, err)
+	}
+
+	return &docsPage{
+		category: category,
+		name:     name,
+		file:     file,
+
+		title:       fm["page_title"],
+		subcategory: fm["subcategory"],
+		description: strings.TrimSpace(fm["description"]),
+	}, nil
+}
+
+// docsPageGroups are the pages of the rendered website grouped by their
+// subcategory frontmatter and category.
+type docsPageGroups struct {
+	index *docsPage
+
+	// subcategories are sorted, pages without a subcategory are grouped
+	// under the empty subcategory which is always last
+	subcategories []string
+
+	// pages are keyed by subcategory and category dir, and sorted by their
+	// navigation title
+	pages map[string]map[string][]*docsPage
+}
+
+func groupDocsPages(pages []*docsPage, providerShortName string) docsPageGroups {
+	groups := docsPageGroups{
+		pages: map[string]map[string][]*docsPage{},
+	}
+
+	for _, p := range pages {
+		if p.category == "" {
+			if groups.index == nil {
+				groups.index = p
+			}
+			continue
+		}
+		if groups.pages[p.subcategory] == nil {
+			groups.pages[p.subcategory] = map[string][]*docsPage{}
+		}
+		groups.pages[p.subcategory][p.category] = append(groups.pages[p.subcategory][p.category], p)
+	}
+
+	for sc, categories := range groups.pages {
+		if sc != "" {
+			groups.subcategories = append(groups.subcategories, sc)
+		}
+		for _, categoryPages := range categories {
+			sort.Slice(categoryPages, func(i, j int) bool {
+				return categoryPages[i].NavTitle(providerShortName) < categoryPages[j].NavTitle(providerShortName)
+			})
+		}
+	}
+	sort.Strings(groups.subcategories)
+	if _, ok := groups.pages[""]; ok {
+		groups.subcategories = append(groups.subcategories, "")
+	}
+
+	return groups
+}
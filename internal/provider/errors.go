@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// renderError is the failure to render a single file, rendering continues with
+// the other files and all failures are reported together.
+type renderError struct {
+	// file is the template or generated file, relative to the provider dir
+	// unless absolute
+	file string
+	// line is 0 if unknown
+	line int
+	err  error
+}
+
+func newRenderError(file string, err error) *renderError {
+	return &renderError{
+		file: file,
+		line: templateErrorLine(err),
+		err:  err,
+	}
+}
+
+func (e *renderError) Error() string {
+	if e.line > 0 {
+		return fmt.Sprintf("%s:%d: %s", e.file, e.line, e.err)
+	}
+	return fmt.Sprintf("%s: %s", e.file, e.err)
+}
+
+func (e *renderError) Unwrap() error {
+	return e.err
+}
+
+// templateErrorLinePattern matches the location in parse and execution errors
+// of text/template, for example "template: resourceTemplate:12:3: executing".
+var templateErrorLinePattern = regexp.MustCompile(`template: [^:\s]+:(\d+)`)
+
+// templateErrorLine returns the line of the template the error occurred at, or
+// 0 if the error is not a template error.
+func templateErrorLine(err error) int {
+	m := templateErrorLinePattern.FindStringSubmatch(err.Error())
+	if m == nil {
+		return 0
+	}
+	line, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0
+	}
+	return line
+}
+
+// appendRenderErrors appends err to errs if it is a render error or a list of
+// render errors, and returns false for any other error which should abort
+// rendering.
+func appendRenderErrors(errs *multierror.Error, err error) (*multierror.Error, bool) {
+	switch err := err.(type) {
+	case nil:
+		return errs, true
+	case *renderError:
+		return appendRenderError(errs, err), true
+	case *multierror.Error:
+		for _, e := range err.Errors {
+			if _, ok := e.(*renderError); !ok {
+				return errs, false
+			}
+		}
+		for _, e := range err.Errors {
+			errs = appendRenderError(errs, e.(*renderError))
+		}
+		return errs, true
+	}
+	return errs, false
+}
+
+func appendRenderError(errs *multierror.Error, err *renderError) *multierror.Error {
+	errs = multierror.Append(errs, err)
+	errs.ErrorFormat = formatRenderErrors
+	return errs
+}
+
+// formatRenderErrors formats the report of all files that failed to render.
+func formatRenderErrors(errs []error) string {
+	var sb strings.Builder
+	if len(errs) == 1 {
+		sb.WriteString("1 file failed to render:\n")
+	} else {
+		fmt.Fprintf(&sb, "%d files failed to render:\n", len(errs))
+	}
+	for _, err := range errs {
+		sb.WriteString("\n  ")
+		sb.WriteString(err.Error())
+	}
+	return sb.String()
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"testing"
+	"text/template"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+func TestTemplateErrorLine(t *testing.T) {
+	execErr := func(text string) error {
+		tmpl, err := template.New("resourceTemplate").Parse(text)
+		if err != nil {
+			return err
+		}
+		return tmpl.Execute(ioutil.Discard, struct{}{})
+	}
+
+	for _, c := range []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{
+			"parse error",
+			func() error {
+				_, err := template.New("resourceTemplate").Parse("a\nb\n{{ .Foo ")
+				return err
+			}(),
+			3,
+		},
+		{
+			"execution error",
+			execErr("a\n\n\n\n{{ .Foo }}"),
+			5,
+		},
+		{
+			"undefined function",
+			func() error {
+				_, err := template.New("resourceTemplate").Parse("a\n{{ foo }}")
+				return err
+			}(),
+			2,
+		},
+		{
+			"wrapped error",
+			fmt.Errorf("unable to render: %w", errors.New("template: docs:12:3: executing \"docs\"")),
+			12,
+		},
+		{
+			"other error",
+			errors.New("open templates/index.md.tmpl: no such file or directory"),
+			0,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if c.err == nil {
+				t.Fatalf("expected an error to parse")
+			}
+			actual := templateErrorLine(c.err)
+			if actual != c.expected {
+				t.Fatalf("expected line %d, got %d for %q", c.expected, actual, c.err)
+			}
+		})
+	}
+}
+
+func TestRenderErrorError(t *testing.T) {
+	err := newRenderError("templates/index.md.tmpl", errors.New(`template: index:4: function "foo" not defined`))
+	expected := `templates/index.md.tmpl:4: template: index:4: function "foo" not defined`
+	if err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err.Error())
+	}
+
+	err = newRenderError("templates/index.md.tmpl", errors.New("permission denied"))
+	expected = "templates/index.md.tmpl: permission denied"
+	if err.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, err.Error())
+	}
+}
+
+func TestAppendRenderErrors(t *testing.T) {
+	a := newRenderError("a.md.tmpl", errors.New("a"))
+	b := newRenderError("b.md.tmpl", errors.New("b"))
+
+	errs, ok := appendRenderErrors(nil, nil)
+	if !ok || errs != nil {
+		t.Fatalf("expected nil to be ignored, got %v", errs)
+	}
+
+	errs, ok = appendRenderErrors(errs, a)
+	if !ok {
+		t.Fatalf("expected render error to be appended")
+	}
+
+	errs, ok = appendRenderErrors(errs, multierror.Append(nil, b))
+	if !ok {
+		t.Fatalf("expected list of render errors to be appended")
+	}
+
+	expected := "2 files failed to render:\n\n  a.md.tmpl: a\n  b.md.tmpl: b"
+	if errs.Error() != expected {
+		t.Fatalf("expected %q, got %q", expected, errs.Error())
+	}
+
+	_, ok = appendRenderErrors(errs, errors.New("disk full"))
+	if ok {
+		t.Fatalf("expected other errors to abort rendering")
+	}
+
+	_, ok = appendRenderErrors(errs, multierror.Append(nil, a, errors.New("disk full")))
+	if ok {
+		t.Fatalf("expected a list containing other errors to abort rendering")
+	}
+}
@@ -0,0 +1,242 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// Meta-arguments that are accepted in the blocks of examples regardless of the
+// provider schema.
+var (
+	resourceMetaArguments = map[string]bool{
+		"count":      true,
+		"depends_on": true,
+		"for_each":   true,
+		"provider":   true,
+	}
+	resourceMetaBlocks = map[string]bool{
+		"connection":  true,
+		"lifecycle":   true,
+		"provisioner": true,
+	}
+	dataSourceMetaBlocks = map[string]bool{
+		"lifecycle": true,
+	}
+	providerMetaArguments = map[string]bool{
+		"alias":   true,
+		"version": true,
+	}
+)
+
+// checkExamples parses every Terraform file of the examples dir, checks that
+// import.sh files are only placed in resource examples and, if a provider
+// schema is available, that the resources, data sources and provider
+// configurations of the provider only use types and arguments in the schema.
+func checkExamples(providerShortName string, providerSchema *tfjson.ProviderSchema) check {
+	return func(dir string) ([]issue, error) {
+		issues := []issue{}
+		parser := hclparse.NewParser()
+
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			parts := strings.Split(filepath.ToSlash(rel), "/")
+
+			switch {
+			case info.Name() == "import.sh":
+				if len(parts) != 3 || parts[0] != "resources" {
+					issues = append(issues, issue{
+						file:    path,
+						message: "import.sh is only supported in resource examples, resources/<resource name>/import.sh",
+					})
+				}
+			case filepath.Ext(path) == ".tf":
+				f, diags := parser.ParseHCLFile(path)
+				issues = append(issues, diagnosticIssues(path, diags)...)
+				if diags.HasErrors() || providerSchema == nil {
+					return nil
+				}
+
+				body, ok := f.Body.(*hclsyntax.Body)
+				if !ok {
+					return nil
+				}
+				issues = append(issues, checkExampleBody(path, providerShortName, providerSchema, body)...)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return issues, nil
+	}
+}
+
+func diagnosticIssues(path string, diags hcl.Diagnostics) []issue {
+	issues := []issue{}
+	for _, diag := range diags {
+		if diag.Severity != hcl.DiagError {
+			continue
+		}
+
+		i := issue{
+			file:    path,
+			message: diag.Summary,
+		}
+		if diag.Detail != "" {
+			i.message += "; " + diag.Detail
+		}
+		if diag.Subject != nil {
+			i.line = diag.Subject.Start.Line
+		}
+		issues = append(issues, i)
+	}
+	return issues
+}
+
+// checkExampleBody checks the top level blocks of a Terraform file that belong
+// to the provider against the provider schema. Blocks of other providers are
+// skipped.
+func checkExampleBody(path, providerShortName string, providerSchema *tfjson.ProviderSchema, body *hclsyntax.Body) []issue {
+	issues := []issue{}
+
+	belongsToProvider := func(typeName string) bool {
+		return typeName == providerShortName || strings.HasPrefix(typeName, providerShortName+"_")
+	}
+
+	for _, block := range body.Blocks {
+		if len(block.Labels) == 0 || !belongsToProvider(block.Labels[0]) {
+			continue
+		}
+		typeName := block.Labels[0]
+
+		var (
+			schema      *tfjson.Schema
+			kind        string
+			metaArgs    map[string]bool
+			metaBlocks  map[string]bool
+			schemaFound bool
+		)
+		switch block.Type {
+		case "resource":
+			schema, schemaFound = providerSchema.ResourceSchemas[typeName]
+			kind = "resource"
+			metaArgs, metaBlocks = resourceMetaArguments, resourceMetaBlocks
+		case "data":
+			schema, schemaFound = providerSchema.DataSourceSchemas[typeName]
+			kind = "data source"
+			metaArgs, metaBlocks = resourceMetaArguments, dataSourceMetaBlocks
+		case "provider":
+			schema, schemaFound = providerSchema.ConfigSchema, providerSchema.ConfigSchema != nil
+			kind = "provider"
+			metaArgs = providerMetaArguments
+		default:
+			continue
+		}
+
+		if !schemaFound {
+			issues = append(issues, issue{
+				file:    path,
+				line:    block.TypeRange.Start.Line,
+				message: fmt.Sprintf("%s %q does not exist in the provider schema", kind, typeName),
+			})
+			continue
+		}
+		if schema == nil || schema.Block == nil {
+			continue
+		}
+
+		issues = append(issues, checkExampleBlock(path, fmt.Sprintf("%s %q", kind, typeName), schema.Block, block.Body, metaArgs, metaBlocks)...)
+	}
+
+	return issues
+}
+
+// checkExampleBlock checks that the body only sets arguments and nested blocks
+// of the schema block, or the given meta-arguments and meta-blocks.
+func checkExampleBlock(path, name string, schemaBlock *tfjson.SchemaBlock, body *hclsyntax.Body, metaArgs, metaBlocks map[string]bool) []issue {
+	issues := []issue{}
+
+	attrNames := make([]string, 0, len(body.Attributes))
+	for attrName := range body.Attributes {
+		attrNames = append(attrNames, attrName)
+	}
+	sort.Strings(attrNames)
+
+	for _, attrName := range attrNames {
+		attr := body.Attributes[attrName]
+		if metaArgs[attrName] {
+			continue
+		}
+
+		attrSchema, ok := schemaBlock.Attributes[attrName]
+		switch {
+		case !ok:
+			issues = append(issues, issue{
+				file:    path,
+				line:    attr.SrcRange.Start.Line,
+				message: fmt.Sprintf("argument %q is not supported by %s", attrName, name),
+			})
+		case attrSchema.Computed && !attrSchema.Optional && !attrSchema.Required:
+			issues = append(issues, issue{
+				file:    path,
+				line:    attr.SrcRange.Start.Line,
+				message: fmt.Sprintf("argument %q of %s is read-only", attrName, name),
+			})
+		}
+	}
+
+	for _, block := range body.Blocks {
+		if metaBlocks[block.Type] {
+			continue
+		}
+
+		blockType := block.Type
+		content := block.Body
+		if blockType == "dynamic" && len(block.Labels) > 0 {
+			blockType = block.Labels[0]
+			content = nil
+			for _, b := range block.Body.Blocks {
+				if b.Type == "content" {
+					content = b.Body
+				}
+			}
+		}
+
+		nested, ok := schemaBlock.NestedBlocks[blockType]
+		if !ok || nested.Block == nil {
+			issues = append(issues, issue{
+				file:    path,
+				line:    block.TypeRange.Start.Line,
+				message: fmt.Sprintf("block %q is not supported by %s", blockType, name),
+			})
+			continue
+		}
+		if content == nil {
+			continue
+		}
+
+		issues = append(issues, checkExampleBlock(path, fmt.Sprintf("block %q of %s", blockType, name), nested.Block, content, nil, nil)...)
+	}
+
+	return issues
+}
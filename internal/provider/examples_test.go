@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestCheckExamples(t *testing.T) {
+	dir := filepath.Join("testdata", "examples")
+	providerSchema := &tfjson.ProviderSchema{
+		ConfigSchema: &tfjson.Schema{
+			Block: &tfjson.SchemaBlock{
+				Attributes: map[string]*tfjson.SchemaAttribute{
+					"endpoint": {AttributeType: cty.String, Optional: true, Description: "API endpoint."},
+				},
+			},
+		},
+		ResourceSchemas: map[string]*tfjson.Schema{
+			"example_thing": {
+				Block: &tfjson.SchemaBlock{
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"name": {AttributeType: cty.String, Required: true, Description: "Name of the thing."},
+						"id":   {AttributeType: cty.String, Computed: true, Description: "ID of the thing."},
+					},
+					NestedBlocks: map[string]*tfjson.SchemaBlockType{
+						"setting": {
+							NestingMode: tfjson.SchemaNestingModeList,
+							Block: &tfjson.SchemaBlock{
+								Attributes: map[string]*tfjson.SchemaAttribute{
+									"value": {AttributeType: cty.String, Optional: true},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		DataSourceSchemas: map[string]*tfjson.Schema{
+			"example_thing": {
+				Block: &tfjson.SchemaBlock{
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"name": {AttributeType: cty.String, Required: true, Description: "Name of the thing."},
+					},
+				},
+			},
+		},
+	}
+
+	path := func(rel string) string {
+		return filepath.Join(dir, filepath.FromSlash(rel))
+	}
+
+	issues, err := checkExamples("example", nil)(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	syntaxIssues := []issue{}
+	for _, i := range issues {
+		syntaxIssues = append(syntaxIssues, issue{file: i.file, line: i.line})
+	}
+
+	// without a schema only the syntax and import.sh placement is checked
+	expected := []issue{
+		{file: path("broken/main.tf"), line: 1},
+		{file: path("data-sources/example_thing/import.sh")},
+	}
+	if diff := cmp.Diff(expected, syntaxIssues, cmp.AllowUnexported(issue{})); diff != "" {
+		t.Fatalf("Unexpected issues without schema (-wanted, +got): %s", diff)
+	}
+
+	expected = []issue{
+		{file: path("broken/main.tf"), line: 1},
+		{file: path("data-sources/example_thing/data-source.tf"), line: 4, message: `block "provisioner" is not supported by data source "example_thing"`},
+		{file: path("data-sources/example_thing/import.sh"), message: "import.sh is only supported in resource examples, resources/<resource name>/import.sh"},
+		{file: path("resources/example_thing/resource.tf"), line: 12, message: `argument "valu" is not supported by block "setting" of resource "example_thing"`},
+		{file: path("resources/example_thing/resource.tf"), line: 23, message: `argument "id" of resource "example_thing" is read-only`},
+		{file: path("resources/example_thing/resource.tf"), line: 22, message: `argument "nme" is not supported by resource "example_thing"`},
+		{file: path("resources/example_thing/resource.tf"), line: 25, message: `block "settings" is not supported by resource "example_thing"`},
+		{file: path("resources/example_thing/resource.tf"), line: 28, message: `resource "example_other" does not exist in the provider schema`},
+	}
+	actual, err := checkExamples("example", providerSchema)(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for i := range actual {
+		if actual[i].file == path("broken/main.tf") {
+			// the parser diagnostic is not part of this check
+			actual[i].message = ""
+		}
+	}
+	if diff := cmp.Diff(expected, actual, cmp.AllowUnexported(issue{})); diff != "" {
+		t.Fatalf("Unexpected issues (-wanted, +got): %s", diff)
+	}
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const frontmatterDelimiter = "---"
+
+// splitFrontmatter separates the YAML frontmatter of a Markdown document from
+// its body. Only top level scalar values are returned, as strings, other
+// values such as lists and nested maps are ignored.
+func splitFrontmatter(content string) (map[string]string, string, error) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+
+	if !strings.HasPrefix(content, frontmatterDelimiter+"\n") {
+		return map[string]string{}, content, nil
+	}
+
+	rest := strings.TrimPrefix(content, frontmatterDelimiter+"\n")
+	end := strings.Index(rest, "\n"+frontmatterDelimiter+"\n")
+	var raw, body string
+	switch {
+	case end >= 0:
+		raw = rest[:end]
+		body = rest[end+len(frontmatterDelimiter)+2:]
+	case strings.HasSuffix(rest, "\n"+frontmatterDelimiter):
+		raw = strings.TrimSuffix(rest, "\n"+frontmatterDelimiter)
+	default:
+		return nil, "", fmt.Errorf("frontmatter is not terminated")
+	}
+
+	fm, err := parseFrontmatter(raw)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return fm, body, nil
+}
+
+func parseFrontmatter(raw string) (map[string]string, error) {
+	values := map[string]interface{}{}
+	err := yaml.Unmarshal([]byte(raw), &values)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse frontmatter YAML: %w", err)
+	}
+
+	fm := map[string]string{}
+	for key, value := range values {
+		switch value := value.(type) {
+		case nil:
+			fm[key] = ""
+		case string:
+			fm[key] = value
+		case bool:
+			fm[key] = strconv.FormatBool(value)
+		case int, int64, uint64, float64:
+			fm[key] = fmt.Sprint(value)
+		}
+	}
+
+	return fm, nil
+}
@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSplitFrontmatter(t *testing.T) {
+	for _, c := range []struct {
+		name                string
+		content             string
+		expectedFrontmatter map[string]string
+		expectedBody        string
+		expectedErr         string
+	}{
+		{
+			"no frontmatter",
+			"# Title\n\nBody\n",
+			map[string]string{},
+			"# Title\n\nBody\n",
+			"",
+		},
+		{
+			"scalars",
+			`---
+page_title: "example_thing Resource - terraform-provider-example"
+subcategory: 'Things ''n'' Stuff'
+layout: docs
+# comment
+---
+
+# example_thing
+`,
+			map[string]string{
+				"page_title":  "example_thing Resource - terraform-provider-example",
+				"subcategory": "Things 'n' Stuff",
+				"layout":      "docs",
+			},
+			"\n# example_thing\n",
+			"",
+		},
+		{
+			"literal block scalars",
+			`---
+description: |-
+  Manages a thing.
+
+  With two paragraphs.
+notes: |
+    Indented.
+subcategory: ""
+---
+`,
+			map[string]string{
+				"description": "Manages a thing.\n\nWith two paragraphs.",
+				"notes":       "Indented.\n",
+				"subcategory": "",
+			},
+			"",
+			"",
+		},
+		{
+			"lists and nested values are ignored",
+			"---\ntitle: Thing\ntags:\n  - a\n  - b\nsidebar:\n  order: 1\nweight: 3\ndraft: false\nempty:\n---\nbody",
+			map[string]string{
+				"title":  "Thing",
+				"weight": "3",
+				"draft":  "false",
+				"empty":  "",
+			},
+			"body",
+			"",
+		},
+		{
+			"quoted values spanning lines",
+			"---\ndescription: \"Manages a thing:\n  with a colon\"\nnote: 'it''s # not a comment'\n---\n",
+			map[string]string{
+				"description": "Manages a thing: with a colon",
+				"note":        "it's # not a comment",
+			},
+			"",
+			"",
+		},
+		{
+			"crlf line endings",
+			"---\r\ntitle: Thing\r\n---\r\nbody\r\n",
+			map[string]string{
+				"title": "Thing",
+			},
+			"body\n",
+			"",
+		},
+		{
+			"terminated at end of file",
+			"---\ntitle: Thing\n---",
+			map[string]string{
+				"title": "Thing",
+			},
+			"",
+			"",
+		},
+		{
+			"not terminated",
+			"---\ntitle: Thing\n",
+			nil,
+			"",
+			"frontmatter is not terminated",
+		},
+		{
+			"invalid YAML",
+			"---\ntitle: Thing\njust text\n---\n",
+			nil,
+			"",
+			"unable to parse frontmatter YAML: yaml: line 3: could not find expected ':'",
+		},
+		{
+			"unterminated quoted value",
+			"---\ntitle: \"Thing\n---\n",
+			nil,
+			"",
+			"unable to parse frontmatter YAML: yaml: found unexpected end of stream",
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			fm, body, err := splitFrontmatter(c.content)
+			switch {
+			case c.expectedErr == "" && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case c.expectedErr != "" && err == nil:
+				t.Fatalf("expected error %q, got none", c.expectedErr)
+			case c.expectedErr != "" && err.Error() != c.expectedErr:
+				t.Fatalf("expected error %q, got %q", c.expectedErr, err)
+			}
+
+			if diff := cmp.Diff(c.expectedFrontmatter, fm); diff != "" {
+				t.Fatalf("Unexpected frontmatter (-wanted, +got): %s", diff)
+			}
+			if diff := cmp.Diff(c.expectedBody, body); diff != "" {
+				t.Fatalf("Unexpected body (-wanted, +got): %s", diff)
+			}
+		})
+	}
+}
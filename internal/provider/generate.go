@@ -1,15 +1,23 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"os/signal"
+	"path"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"text/template"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/go-version"
 	install "github.com/hashicorp/hc-install"
 	"github.com/hashicorp/hc-install/checkpoint"
@@ -19,31 +27,44 @@ import (
 	"github.com/hashicorp/hc-install/src"
 	"github.com/hashicorp/terraform-exec/tfexec"
 	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-docs/schemamd"
 	"github.com/mitchellh/cli"
 )
 
-// TODO: convert these to flags?
+// pathTemplates are the conventional paths of examples, relative to the
+// examples dir, and of templates, relative to the website source dir.
+type pathTemplates struct {
+	examplesResourceFile   resourceFileTemplate
+	examplesResourceImport resourceFileTemplate
+	examplesDataSourceFile resourceFileTemplate
+	examplesProviderFile   providerFileTemplate
+
+	websiteResourceFile           resourceFileTemplate
+	websiteResourceFallbackFile   resourceFileTemplate
+	websiteDataSourceFile         resourceFileTemplate
+	websiteDataSourceFallbackFile resourceFileTemplate
+	websiteProviderFile           providerFileTemplate
+}
+
+func defaultPathTemplates() pathTemplates {
+	return pathTemplates{
+		examplesResourceFile:   "resources/{{.Name}}/resource.tf",
+		examplesResourceImport: "resources/{{.Name}}/import.sh",
+		examplesDataSourceFile: "data-sources/{{ .Name }}/data-source.tf",
+		examplesProviderFile:   "provider/provider.tf",
+
+		websiteResourceFile:           "resources/{{ .ShortName }}.md.tmpl",
+		websiteResourceFallbackFile:   "resources.md.tmpl",
+		websiteDataSourceFile:         "data-sources/{{ .ShortName }}.md.tmpl",
+		websiteDataSourceFallbackFile: "data-sources.md.tmpl",
+		websiteProviderFile:           "index.md.tmpl",
+	}
+}
+
 var (
-	providerName string
-
-	// rendered website dir
-	renderedWebsiteDir = "docs"
-
-	// examples directory defaults
-	examplesDir = "examples"
-	// relative to examples dir
-	examplesResourceFileTemplate   = resourceFileTemplate("resources/{{.Name}}/resource.tf")
-	examplesResourceImportTemplate = resourceFileTemplate("resources/{{.Name}}/import.sh")
-	examplesDataSourceFileTemplate = resourceFileTemplate("data-sources/{{ .Name }}/data-source.tf")
-	examplesProviderFileTemplate   = providerFileTemplate("provider/provider.tf")
-
-	// templated website directory defaults
-	websiteTmp = ""
-
-	websiteSourceDir                    = "templates" // used for override content
-	websiteResourceFileTemplate         = resourceFileTemplate("resources/{{ .ShortName }}.md.tmpl")
-	websiteResourceFallbackFileTemplate = resourceFileTemplate("resources.md.tmpl")
-	websiteResourceFileStatic           = []resourceFileTemplate{
+	// static candidates, relative to website source dir
+	websiteResourceFileStatic = []resourceFileTemplate{
 		resourceFileTemplate("resources/{{ .ShortName }}.md"),
 		// TODO: warn for all of these, as they won't render? massage them to the proper output file name?
 		resourceFileTemplate("resources/{{ .ShortName }}.markdown"),
@@ -54,9 +75,7 @@ var (
 		resourceFileTemplate("r/{{ .ShortName }}.html.markdown"),
 		resourceFileTemplate("r/{{ .ShortName }}.html.md"),
 	}
-	websiteDataSourceFileTemplate         = resourceFileTemplate("data-sources/{{ .ShortName }}.md.tmpl")
-	websiteDataSourceFallbackFileTemplate = resourceFileTemplate("data-sources.md.tmpl")
-	websiteDataSourceFileStatic           = []resourceFileTemplate{
+	websiteDataSourceFileStatic = []resourceFileTemplate{
 		resourceFileTemplate("data-sources/{{ .ShortName }}.md"),
 		// TODO: warn for all of these, as they won't render? massage them to the proper output file name?
 		resourceFileTemplate("data-sources/{{ .ShortName }}.markdown"),
@@ -67,8 +86,7 @@ var (
 		resourceFileTemplate("d/{{ .ShortName }}.html.markdown"),
 		resourceFileTemplate("d/{{ .ShortName }}.html.md"),
 	}
-	websiteProviderFileTemplate = providerFileTemplate("index.md.tmpl")
-	websiteProviderFileStatic   = []providerFileTemplate{
+	websiteProviderFileStatic = []providerFileTemplate{
 		providerFileTemplate("index.markdown"),
 		providerFileTemplate("index.md"),
 		providerFileTemplate("index.html.markdown"),
@@ -77,12 +95,109 @@ var (
 )
 
 type generator struct {
-	legacySidebar bool
-	tfVersion     string
+	// providerDir is the absolute path to the root provider directory
+	providerDir    string
+	providerName   string
+	providerSource providerSource
+
+	// providerSourceAddress is the unparsed provider source, defaulting to
+	// the hashicorp namespace if empty
+	providerSourceAddress string
+
+	// directories relative to the provider dir, unless absolute
+	renderedWebsiteDir string
+	examplesDir        string
+	websiteSourceDir   string // used for override content
+
+	websiteTmpDir string
+
+	paths  pathTemplates
+	config *config
+
+	// schemaOptions configure the rendering of schemas, dataSourceSchemaOptions
+	// of data source schemas
+	schemaOptions           schemamd.Options
+	dataSourceSchemaOptions schemamd.Options
+
+	// targets is populated for each render of the website
+	targets *templateTargets
+
+	// generated are the slash separated paths of the files rendered to the
+	// rendered website dir, written to its manifest
+	generated map[string]bool
+
+	// parallelism is the maximum number of files rendered at the same time
+	parallelism int
+
+	// mu guards generated, it is shared by the copies of the generator used
+	// by render tasks
+	mu *sync.Mutex
+
+	legacySidebar       bool
+	tfVersion           string
+	providersSchemaPath string
+	watch               bool
+
+	// check renders to a scratch dir and compares it with the provider docs
+	// dir instead of writing to it
+	check bool
+
+	// initManifest treats the existing files of a rendered website dir without
+	// a manifest as generated, so those no longer generated are removed
+	initManifest bool
 
 	ui cli.Ui
 }
 
+func (g *generator) providerDocsDir() string {
+	return resolvePath(g.providerDir, g.renderedWebsiteDir)
+}
+
+func (g *generator) providerExamplesDir() string {
+	return resolvePath(g.providerDir, g.examplesDir)
+}
+
+func (g *generator) providerTemplatesDir() string {
+	return resolvePath(g.providerDir, g.websiteSourceDir)
+}
+
+// providersSchemaFile returns the path of the providers schema file, empty if
+// the schema is exported from Terraform.
+func (g *generator) providersSchemaFile() string {
+	if g.providersSchemaPath == "" {
+		return ""
+	}
+	return resolvePath(g.providerDir, g.providersSchemaPath)
+}
+
+func (g *generator) tempTemplatesDir() string {
+	return filepath.Join(g.websiteTmpDir, "templates")
+}
+
+// templateFile returns the path of a template in the tmp dir as it is shown to
+// users: its source in the website source dir if it exists there, otherwise
+// the file it renders to in the rendered website dir.
+func (g *generator) templateFile(tmpPath string) string {
+	rel, err := filepath.Rel(g.tempTemplatesDir(), tmpPath)
+	if err != nil {
+		return tmpPath
+	}
+	if fileExists(filepath.Join(g.providerTemplatesDir(), rel)) {
+		return filepath.Join(g.websiteSourceDir, rel)
+	}
+	return g.renderedFile(tmpPath)
+}
+
+// renderedFile returns the path in the rendered website dir, relative to the
+// provider dir unless absolute, that a template in the tmp dir renders to.
+func (g *generator) renderedFile(tmpPath string) string {
+	rel, err := filepath.Rel(g.tempTemplatesDir(), tmpPath)
+	if err != nil {
+		return tmpPath
+	}
+	return filepath.Join(g.renderedWebsiteDir, strings.TrimSuffix(rel, ".tmpl"))
+}
+
 func (g *generator) infof(format string, a ...interface{}) {
 	g.ui.Info(fmt.Sprintf(format, a...))
 }
@@ -91,15 +206,80 @@ func (g *generator) warnf(format string, a ...interface{}) {
 	g.ui.Warn(fmt.Sprintf(format, a...))
 }
 
-func Generate(ui cli.Ui, legacySidebar bool, tfVersion string) error {
+// GenerateOptions configures Generate. Empty directories and names fall back to
+// the .tfplugindocs.hcl config of the provider and then to the defaults.
+type GenerateOptions struct {
+	ProviderDir         string
+	ProviderName        string
+	ProviderSource      string
+	RenderedWebsiteDir  string
+	ExamplesDir         string
+	WebsiteTmpDir       string
+	WebsiteSourceDir    string
+	TFVersion           string
+	ProvidersSchemaPath string
+	ConfigPath          string
+
+	LegacySidebar bool
+	Watch         bool
+	Check         bool
+	InitManifest  bool
+	Parallelism   int
+}
+
+func Generate(ui cli.Ui, opts GenerateOptions) error {
+	providerDir, err := resolveProviderDir(opts.ProviderDir)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(providerDir, opts.ConfigPath)
+	if err != nil {
+		return err
+	}
+
+	websiteTmpDir := opts.WebsiteTmpDir
+	if websiteTmpDir == "" && cfg.WebsiteTmpDir != "" {
+		websiteTmpDir = resolvePath(providerDir, cfg.WebsiteTmpDir)
+	}
+
 	g := &generator{
-		legacySidebar: legacySidebar,
-		tfVersion:     tfVersion,
+		providerDir:  providerDir,
+		providerName: stringOrDefault(opts.ProviderName, cfg.ProviderName),
+
+		providerSourceAddress: stringOrDefault(opts.ProviderSource, cfg.ProviderSource),
+
+		renderedWebsiteDir: stringOrDefault(opts.RenderedWebsiteDir, cfg.RenderedWebsiteDir, "docs"),
+		examplesDir:        stringOrDefault(opts.ExamplesDir, cfg.ExamplesDir, "examples"),
+		websiteSourceDir:   stringOrDefault(opts.WebsiteSourceDir, cfg.WebsiteSourceDir, "templates"),
+
+		websiteTmpDir: websiteTmpDir,
+
+		paths:  cfg.pathTemplates(),
+		config: cfg,
+
+		schemaOptions:           cfg.schemaOptions(),
+		dataSourceSchemaOptions: cfg.dataSourceSchemaOptions(),
+
+		legacySidebar:       opts.LegacySidebar,
+		tfVersion:           opts.TFVersion,
+		providersSchemaPath: opts.ProvidersSchemaPath,
+		watch:               opts.Watch,
+		check:               opts.Check,
+		initManifest:        opts.InitManifest,
+
+		parallelism: opts.Parallelism,
+		mu:          &sync.Mutex{},
 
 		ui: ui,
 	}
 
 	ctx := context.Background()
+	if opts.Watch {
+		var cancel context.CancelFunc
+		ctx, cancel = signal.NotifyContext(ctx, os.Interrupt)
+		defer cancel()
+	}
 
 	return g.Generate(ctx)
 }
@@ -107,39 +287,74 @@ func Generate(ui cli.Ui, legacySidebar bool, tfVersion string) error {
 func (g *generator) Generate(ctx context.Context) error {
 	var err error
 
-	wd, err := os.Getwd()
+	if g.providerName == "" {
+		g.providerName = filepath.Base(g.providerDir)
+	}
+
+	if g.watch && g.check {
+		return fmt.Errorf("watching and checking the rendered website can not be combined")
+	}
+
+	g.providerSource, err = parseProviderSource(g.providerSourceAddress, providerShortName(g.providerName))
 	if err != nil {
 		return err
 	}
-
-	if providerName == "" {
-		providerName = filepath.Base(wd)
+	if g.providerSource.typeName != providerShortName(g.providerName) {
+		return fmt.Errorf("provider source %q does not match provider name %q", g.providerSource, g.providerName)
 	}
 
-	g.infof("rendering website for provider %q", providerName)
+	g.infof("rendering website for provider %q (as %q)", g.providerName, g.providerDir)
 
 	switch {
-	case websiteTmp == "":
-		websiteTmp, err = ioutil.TempDir("", "tfws")
+	case g.websiteTmpDir == "":
+		g.websiteTmpDir, err = ioutil.TempDir("", "tfws")
 		if err != nil {
 			return err
 		}
-		defer os.RemoveAll(websiteTmp)
+		defer os.RemoveAll(g.websiteTmpDir)
 	default:
-		g.infof("cleaning tmp dir %q", websiteTmp)
-		err = os.RemoveAll(websiteTmp)
+		g.infof("cleaning tmp dir %q", g.websiteTmpDir)
+		err = os.RemoveAll(g.websiteTmpDir)
 		if err != nil {
 			return err
 		}
 
-		g.infof("creating tmp dir %q", websiteTmp)
-		err = os.MkdirAll(websiteTmp, 0755)
+		g.infof("creating tmp dir %q", g.websiteTmpDir)
+		err = os.MkdirAll(g.websiteTmpDir, 0755)
 		if err != nil {
 			return err
 		}
 	}
 
-	websiteSourceDirInfo, err := os.Stat(websiteSourceDir)
+	err = g.copyTemplates()
+	if err != nil {
+		return err
+	}
+
+	providerSchema, err := g.providerSchema(ctx, g.providerName)
+	if err != nil {
+		return err
+	}
+
+	err = g.render(providerSchema)
+	if err != nil {
+		return err
+	}
+
+	if g.watch {
+		return g.watchChanges(ctx, providerSchema)
+	}
+
+	if g.check {
+		return g.checkRendered()
+	}
+
+	return nil
+}
+
+// copyTemplates copies the template directory, if present, to the tmp dir.
+func (g *generator) copyTemplates() error {
+	websiteSourceDirInfo, err := os.Stat(g.providerTemplatesDir())
 	switch {
 	case os.IsNotExist(err):
 		// do nothing, no template dir
@@ -147,72 +362,83 @@ func (g *generator) Generate(ctx context.Context) error {
 		return err
 	default:
 		if !websiteSourceDirInfo.IsDir() {
-			return fmt.Errorf("template path is not a directory: %s", websiteSourceDir)
+			return fmt.Errorf("template path is not a directory: %s", g.providerTemplatesDir())
 		}
 
 		g.infof("copying any existing content to tmp dir")
-		err = cp(websiteSourceDir, filepath.Join(websiteTmp, "templates"))
+		err = cp(g.providerTemplatesDir(), g.tempTemplatesDir())
 		if err != nil {
 			return err
 		}
 	}
 
-	g.infof("exporting schema from Terraform")
-	providerSchema, err := g.terraformProviderSchema(ctx, providerName)
+	return nil
+}
+
+func (g *generator) render(providerSchema *tfjson.ProviderSchema) error {
+	var err error
+	g.targets, err = g.templateTargets(providerSchema)
 	if err != nil {
 		return err
 	}
 
+	// files that fail to render are collected and reported together, other
+	// errors abort rendering
+	var renderErrs *multierror.Error
+	var ok bool
+
 	g.infof("rendering missing docs")
-	err = g.renderMissingDocs(providerName, providerSchema)
-	if err != nil {
+	err = g.renderMissingDocs(g.providerName, providerSchema)
+	if renderErrs, ok = appendRenderErrors(renderErrs, err); !ok {
 		return err
 	}
 
 	g.infof("rendering static website")
-	err = g.renderStaticWebsite(providerName, providerSchema)
-	if err != nil {
+	err = g.renderStaticWebsite(g.providerName, providerSchema)
+	if renderErrs, ok = appendRenderErrors(renderErrs, err); !ok {
 		return err
 	}
 
-	// TODO: may not ever need this, unsure on when this will go live
 	if g.legacySidebar {
-		g.infof("rendering legacy sidebar...")
-		g.warnf("TODO...!")
+		g.infof("rendering legacy sidebar")
+		err = g.renderLegacySidebar()
+		if err != nil {
+			return err
+		}
 	}
 
-	return nil
+	return renderErrs.ErrorOrNil()
 }
 
-func (g *generator) renderMissingResourceDoc(providerName, name, typeName string, schema *tfjson.Schema, websiteFileTemplate resourceFileTemplate, fallbackWebsiteFileTemplate resourceFileTemplate, websiteStaticCandidateTemplates []resourceFileTemplate, examplesFileTemplate resourceFileTemplate, examplesImportTemplate *resourceFileTemplate) error {
-	tmplPath, err := websiteFileTemplate.Render(name, providerName)
+func (g *generator) renderMissingResourceDoc(providerName string, providerSchema *tfjson.ProviderSchema, name, typeName string, schema *tfjson.Schema, websiteFileTemplate resourceFileTemplate, fallbackWebsiteFileTemplate resourceFileTemplate, websiteStaticCandidateTemplates []resourceFileTemplate, examplesFileTemplate resourceFileTemplate, examplesImportTemplate *resourceFileTemplate) error {
+	tmplPath, err := websiteFileTemplate.Render(g.providerDir, name, providerName)
 	if err != nil {
 		return fmt.Errorf("unable to render path for resource %q: %w", name, err)
 	}
-	tmplPath = filepath.Join(websiteTmp, websiteSourceDir, tmplPath)
+	tmplPath = filepath.Join(g.tempTemplatesDir(), tmplPath)
 	if fileExists(tmplPath) {
 		g.infof("resource %q template exists, skipping", name)
 		return nil
 	}
 
 	for _, candidate := range websiteStaticCandidateTemplates {
-		candidatePath, err := candidate.Render(name, providerName)
+		candidatePath, err := candidate.Render(g.providerDir, name, providerName)
 		if err != nil {
 			return fmt.Errorf("unable to render path for resource %q: %w", name, err)
 		}
-		candidatePath = filepath.Join(websiteTmp, websiteSourceDir, candidatePath)
+		candidatePath = filepath.Join(g.tempTemplatesDir(), candidatePath)
 		if fileExists(candidatePath) {
 			g.infof("resource %q static file exists, skipping", name)
 			return nil
 		}
 	}
 
-	examplePath, err := examplesFileTemplate.Render(name, providerName)
+	examplePath, err := examplesFileTemplate.Render(g.providerDir, name, providerName)
 	if err != nil {
 		return fmt.Errorf("unable to render example file path for %q: %w", name, err)
 	}
 	if examplePath != "" {
-		examplePath = filepath.Join(examplesDir, examplePath)
+		examplePath = filepath.Join(g.providerExamplesDir(), examplePath)
 	}
 	if !fileExists(examplePath) {
 		examplePath = ""
@@ -220,12 +446,12 @@ func (g *generator) renderMissingResourceDoc(providerName, name, typeName string
 
 	importPath := ""
 	if examplesImportTemplate != nil {
-		importPath, err = examplesImportTemplate.Render(name, providerName)
+		importPath, err = examplesImportTemplate.Render(g.providerDir, name, providerName)
 		if err != nil {
 			return fmt.Errorf("unable to render example import file path for %q: %w", name, err)
 		}
 		if importPath != "" {
-			importPath = filepath.Join(examplesDir, importPath)
+			importPath = filepath.Join(g.providerExamplesDir(), importPath)
 		}
 		if !fileExists(importPath) {
 			importPath = ""
@@ -234,11 +460,11 @@ func (g *generator) renderMissingResourceDoc(providerName, name, typeName string
 
 	targetResourceTemplate := defaultResourceTemplate
 
-	fallbackTmplPath, err := fallbackWebsiteFileTemplate.Render(name, providerName)
+	fallbackTmplPath, err := fallbackWebsiteFileTemplate.Render(g.providerDir, name, providerName)
 	if err != nil {
 		return fmt.Errorf("unable to render path for resource %q: %w", name, err)
 	}
-	fallbackTmplPath = filepath.Join(websiteTmp, websiteSourceDir, fallbackTmplPath)
+	fallbackTmplPath = filepath.Join(g.tempTemplatesDir(), fallbackTmplPath)
 	if fileExists(fallbackTmplPath) {
 		g.infof("resource %q fallback template exists", name)
 		tmplData, err := ioutil.ReadFile(fallbackTmplPath)
@@ -249,9 +475,22 @@ func (g *generator) renderMissingResourceDoc(providerName, name, typeName string
 	}
 
 	g.infof("generating template for %q", name)
-	md, err := targetResourceTemplate.Render(name, providerName, typeName, examplePath, importPath, schema)
+	md, err := targetResourceTemplate.Render(g.providerDir, resourcePage{
+		name:          name,
+		providerName:  providerName,
+		typeName:      typeName,
+		subcategory:   g.subcategory(typeName, name),
+		exampleFile:   examplePath,
+		importFile:    importPath,
+		schema:        schema,
+		schemaOptions: g.schemaOptionsFor(typeName),
+	}, g.schemaFuncs(providerSchema))
 	if err != nil {
-		return fmt.Errorf("unable to render template for %q: %w", name, err)
+		file := g.renderedFile(tmplPath)
+		if targetResourceTemplate != defaultResourceTemplate {
+			file = g.templateFile(fallbackTmplPath)
+		}
+		return newRenderError(file, fmt.Errorf("unable to render template for %q: %w", name, err))
 	}
 
 	err = writeFile(tmplPath, md)
@@ -262,44 +501,44 @@ func (g *generator) renderMissingResourceDoc(providerName, name, typeName string
 	return nil
 }
 
-func (g *generator) renderMissingProviderDoc(providerName string, schema *tfjson.Schema, websiteFileTemplate providerFileTemplate, websiteStaticCandidateTemplates []providerFileTemplate, examplesFileTemplate providerFileTemplate) error {
-	tmplPath, err := websiteFileTemplate.Render(providerName)
+func (g *generator) renderMissingProviderDoc(providerName string, providerSchema *tfjson.ProviderSchema, schema *tfjson.Schema, websiteFileTemplate providerFileTemplate, websiteStaticCandidateTemplates []providerFileTemplate, examplesFileTemplate providerFileTemplate) error {
+	tmplPath, err := websiteFileTemplate.Render(g.providerDir, providerName)
 	if err != nil {
 		return fmt.Errorf("unable to render path for provider %q: %w", providerName, err)
 	}
-	tmplPath = filepath.Join(websiteTmp, websiteSourceDir, tmplPath)
+	tmplPath = filepath.Join(g.tempTemplatesDir(), tmplPath)
 	if fileExists(tmplPath) {
 		g.infof("provider %q template exists, skipping", providerName)
 		return nil
 	}
 
 	for _, candidate := range websiteStaticCandidateTemplates {
-		candidatePath, err := candidate.Render(providerName)
+		candidatePath, err := candidate.Render(g.providerDir, providerName)
 		if err != nil {
 			return fmt.Errorf("unable to render path for provider %q: %w", providerName, err)
 		}
-		candidatePath = filepath.Join(websiteTmp, websiteSourceDir, candidatePath)
+		candidatePath = filepath.Join(g.tempTemplatesDir(), candidatePath)
 		if fileExists(candidatePath) {
 			g.infof("provider %q static file exists, skipping", providerName)
 			return nil
 		}
 	}
 
-	examplePath, err := examplesFileTemplate.Render(providerName)
+	examplePath, err := examplesFileTemplate.Render(g.providerDir, providerName)
 	if err != nil {
 		return fmt.Errorf("unable to render example file path for %q: %w", providerName, err)
 	}
 	if examplePath != "" {
-		examplePath = filepath.Join(examplesDir, examplePath)
+		examplePath = filepath.Join(g.providerExamplesDir(), examplePath)
 	}
 	if !fileExists(examplePath) {
 		examplePath = ""
 	}
 
 	g.infof("generating template for %q", providerName)
-	md, err := defaultProviderTemplate.Render(providerName, examplePath, schema)
+	md, err := defaultProviderTemplate.Render(g.providerDir, providerName, examplePath, schema, g.schemaOptions, g.schemaFuncs(providerSchema))
 	if err != nil {
-		return fmt.Errorf("unable to render template for %q: %w", providerName, err)
+		return newRenderError(g.renderedFile(tmplPath), fmt.Errorf("unable to render template for %q: %w", providerName, err))
 	}
 
 	err = writeFile(tmplPath, md)
@@ -311,159 +550,528 @@ func (g *generator) renderMissingProviderDoc(providerName string, schema *tfjson
 }
 
 func (g *generator) renderMissingDocs(providerName string, providerSchema *tfjson.ProviderSchema) error {
+	tasks := []renderTask{}
+
 	g.infof("generating missing resource content")
-	for name, schema := range providerSchema.ResourceSchemas {
-		err := g.renderMissingResourceDoc(providerName, name, "Resource", schema,
-			websiteResourceFileTemplate,
-			websiteResourceFallbackFileTemplate,
-			websiteResourceFileStatic,
-			examplesResourceFileTemplate,
-			&examplesResourceImportTemplate)
-		if err != nil {
-			return fmt.Errorf("unable to render doc %q: %w", name, err)
-		}
+	for _, name := range sortedSchemaNames(providerSchema.ResourceSchemas) {
+		name, schema := name, providerSchema.ResourceSchemas[name]
+		tasks = append(tasks, func(g *generator) error {
+			err := g.renderMissingResourceDoc(providerName, providerSchema, name, "Resource", schema,
+				g.paths.websiteResourceFile,
+				g.paths.websiteResourceFallbackFile,
+				websiteResourceFileStatic,
+				g.paths.examplesResourceFile,
+				&g.paths.examplesResourceImport)
+			if _, ok := err.(*renderError); !ok && err != nil {
+				return fmt.Errorf("unable to render doc %q: %w", name, err)
+			}
+			return err
+		})
+	}
+
+	// continue with the data sources if only some resources failed to render
+	var renderErrs *multierror.Error
+	var ok bool
+
+	err := g.renderParallel(tasks)
+	if renderErrs, ok = appendRenderErrors(renderErrs, err); !ok {
+		return err
 	}
 
+	tasks = []renderTask{}
+
 	g.infof("generating missing data source content")
-	for name, schema := range providerSchema.DataSourceSchemas {
-		err := g.renderMissingResourceDoc(providerName, name, "Data Source", schema,
-			websiteDataSourceFileTemplate,
-			websiteDataSourceFallbackFileTemplate,
-			websiteDataSourceFileStatic,
-			examplesDataSourceFileTemplate,
-			nil)
-		if err != nil {
-			return fmt.Errorf("unable to render doc %q: %w", name, err)
-		}
+	for _, name := range sortedSchemaNames(providerSchema.DataSourceSchemas) {
+		name, schema := name, providerSchema.DataSourceSchemas[name]
+		tasks = append(tasks, func(g *generator) error {
+			err := g.renderMissingResourceDoc(providerName, providerSchema, name, "Data Source", schema,
+				g.paths.websiteDataSourceFile,
+				g.paths.websiteDataSourceFallbackFile,
+				websiteDataSourceFileStatic,
+				g.paths.examplesDataSourceFile,
+				nil)
+			if _, ok := err.(*renderError); !ok && err != nil {
+				return fmt.Errorf("unable to render doc %q: %w", name, err)
+			}
+			return err
+		})
+	}
+
+	err = g.renderParallel(tasks)
+	if renderErrs, ok = appendRenderErrors(renderErrs, err); !ok {
+		return err
 	}
 
 	g.infof("generating missing provider content")
-	err := g.renderMissingProviderDoc(providerName, providerSchema.ConfigSchema,
-		websiteProviderFileTemplate,
+	err = g.renderMissingProviderDoc(providerName, providerSchema, providerSchema.ConfigSchema,
+		g.paths.websiteProviderFile,
 		websiteProviderFileStatic,
-		examplesProviderFileTemplate,
+		g.paths.examplesProviderFile,
 	)
-	if err != nil {
+	if renderErrs, ok = appendRenderErrors(renderErrs, err); !ok {
 		return fmt.Errorf("unable to render provider doc: %w", err)
 	}
 
-	return nil
+	return renderErrs.ErrorOrNil()
+}
+
+func sortedSchemaNames(schemas map[string]*tfjson.Schema) []string {
+	names := make([]string, 0, len(schemas))
+	for name := range schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 func (g *generator) renderStaticWebsite(providerName string, providerSchema *tfjson.ProviderSchema) error {
-	g.infof("cleaning rendered website dir")
-	err := os.RemoveAll(renderedWebsiteDir)
+	previous, hasManifest, err := readManifest(g.renderedDocsDir())
 	if err != nil {
 		return err
 	}
+	switch {
+	case hasManifest || g.check:
+	case g.initManifest:
+		g.infof("no manifest found in rendered website dir, existing files are removed unless generated")
+		previous, err = listFiles(g.renderedDocsDir())
+		if err != nil {
+			return err
+		}
+	default:
+		g.infof("no manifest found in rendered website dir, existing files are kept, run with -init-manifest to remove stale files")
+	}
 
-	shortName := providerShortName(providerName)
+	g.generated = map[string]bool{}
 
 	g.infof("rendering templated website to static markdown")
 
-	err = filepath.Walk(websiteTmp, func(path string, info os.FileInfo, err error) error {
+	tasks := []renderTask{}
+	err = filepath.Walk(g.tempTemplatesDir(), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
 		if info.IsDir() {
 			// skip directories
 			return nil
 		}
 
-		rel, err := filepath.Rel(filepath.Join(websiteTmp, websiteSourceDir), path)
-		if err != nil {
-			return err
+		tasks = append(tasks, func(g *generator) error {
+			err := g.renderTemplateFile(providerName, providerSchema, path)
+			if err != nil {
+				return newRenderError(g.templateFile(path), err)
+			}
+			return nil
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = g.renderParallel(tasks)
+	if err != nil {
+		// keep the files of the previous render so files that failed to
+		// render are not removed as stale
+		for rel := range previous {
+			g.generated[rel] = true
+		}
+		manifestErr := writeManifest(g.renderedDocsDir(), g.generated)
+		if manifestErr != nil {
+			return manifestErr
 		}
+		return err
+	}
 
-		relDir, relFile := filepath.Split(rel)
-		relDir = filepath.ToSlash(relDir)
+	removed, err := removeStaleFiles(g.renderedDocsDir(), previous, g.generated)
+	if err != nil {
+		return err
+	}
+	for _, rel := range removed {
+		g.infof("removed stale file: %q", rel)
+	}
 
-		// skip special top-level generic resource and data source templates
-		if relDir == "" && (relFile == "resources.md.tmpl" || relFile == "data-sources.md.tmpl") {
-			return nil
-		}
+	return writeManifest(g.renderedDocsDir(), g.generated)
+}
+
+// renderTemplateFile renders a single file in the tmp dir to its location in
+// the rendered website dir, template files are executed and other files copied.
+func (g *generator) renderTemplateFile(providerName string, providerSchema *tfjson.ProviderSchema, path string) error {
+	rel, err := filepath.Rel(g.tempTemplatesDir(), path)
+	if err != nil {
+		return err
+	}
+
+	kind, name := g.targets.lookup(filepath.ToSlash(rel), providerName, providerSchema)
 
-		renderedPath := filepath.Join(renderedWebsiteDir, rel)
-		err = os.MkdirAll(filepath.Dir(renderedPath), 0755)
+	// skip special generic resource and data source templates
+	if kind == templateKindFallback {
+		return nil
+	}
+
+	renderedPath := filepath.Join(g.renderedDocsDir(), rel)
+	g.mu.Lock()
+	g.generated[filepath.ToSlash(strings.TrimSuffix(rel, ".tmpl"))] = true
+	g.mu.Unlock()
+
+	err = os.MkdirAll(filepath.Dir(renderedPath), 0755)
+	if err != nil {
+		return err
+	}
+
+	ext := filepath.Ext(path)
+	if ext != ".tmpl" {
+		g.infof("copying non-template file: %q", rel)
+		// the file may have been rendered before, as the rendered website
+		// dir is no longer cleared
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("unable to read file %q: %w", rel, err)
+		}
+		info, err := os.Stat(path)
 		if err != nil {
 			return err
 		}
+		return ioutil.WriteFile(renderedPath, data, info.Mode())
+	}
 
-		ext := filepath.Ext(path)
-		if ext != ".tmpl" {
-			g.infof("copying non-template file: %q", rel)
-			return cp(path, renderedPath)
-		}
+	renderedPath = strings.TrimSuffix(renderedPath, ext)
 
-		renderedPath = strings.TrimSuffix(renderedPath, ext)
+	tmplData, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read file %q: %w", rel, err)
+	}
 
-		tmplData, err := ioutil.ReadFile(path)
+	// render to a buffer first so a template that fails to render does not
+	// leave a truncated file behind
+	var out bytes.Buffer
+
+	g.infof("rendering %q", rel)
+	switch kind {
+	case templateKindDataSource:
+		tmpl := resourceTemplate(tmplData)
+		render, err := tmpl.Render(g.providerDir, resourcePage{
+			name:          name,
+			providerName:  providerName,
+			typeName:      "Data Source",
+			subcategory:   g.subcategory("Data Source", name),
+			schema:        providerSchema.DataSourceSchemas[name],
+			schemaOptions: g.schemaOptionsFor("Data Source"),
+		}, g.schemaFuncs(providerSchema))
 		if err != nil {
-			return fmt.Errorf("unable to read file %q: %w", rel, err)
+			return fmt.Errorf("unable to render data source template %q: %w", rel, err)
+		}
+		out.WriteString(render)
+	case templateKindResource:
+		tmpl := resourceTemplate(tmplData)
+		render, err := tmpl.Render(g.providerDir, resourcePage{
+			name:          name,
+			providerName:  providerName,
+			typeName:      "Resource",
+			subcategory:   g.subcategory("Resource", name),
+			schema:        providerSchema.ResourceSchemas[name],
+			schemaOptions: g.schemaOptionsFor("Resource"),
+		}, g.schemaFuncs(providerSchema))
+		if err != nil {
+			return fmt.Errorf("unable to render resource template %q: %w", rel, err)
+		}
+		out.WriteString(render)
+	case templateKindProvider:
+		tmpl := providerTemplate(tmplData)
+		render, err := tmpl.Render(g.providerDir, providerName, "", providerSchema.ConfigSchema, g.schemaOptions, g.schemaFuncs(providerSchema))
+		if err != nil {
+			return fmt.Errorf("unable to render provider template %q: %w", rel, err)
+		}
+		out.WriteString(render)
+	default:
+		data, err := g.docTemplateData(providerName, providerSchema)
+		if err != nil {
+			return fmt.Errorf("unable to render template %q: %w", rel, err)
 		}
 
-		out, err := os.Create(renderedPath)
+		tmpl := docTemplate(tmplData)
+		err = tmpl.Render(g.providerDir, g.schemaFuncs(providerSchema), &out, data)
 		if err != nil {
-			return err
+			return fmt.Errorf("unable to render template %q: %w", rel, err)
 		}
-		defer out.Close()
+	}
 
-		g.infof("rendering %q", rel)
-		switch relDir {
-		case "data-sources/":
-			resName := shortName + "_" + removeAllExt(relFile)
-			resSchema, ok := providerSchema.DataSourceSchemas[resName]
-			if ok {
-				tmpl := resourceTemplate(tmplData)
-				render, err := tmpl.Render(resName, providerName, "Data Source", "", "", resSchema)
-				if err != nil {
-					return fmt.Errorf("unable to render data source template %q: %w", rel, err)
-				}
-				_, err = out.WriteString(render)
-				if err != nil {
-					return fmt.Errorf("unable to write rendered string: %w", err)
-				}
-				return nil
+	err = ioutil.WriteFile(renderedPath, out.Bytes(), 0644)
+	if err != nil {
+		return fmt.Errorf("unable to write rendered file %q: %w", rel, err)
+	}
+	return nil
+}
+
+// docTemplateData returns the data of doc templates such as guides.
+func (g *generator) docTemplateData(providerName string, providerSchema *tfjson.ProviderSchema) (*docTemplateData, error) {
+	// example paths are relative to the provider dir, so they can be passed
+	// to codefile and tffile
+	examplePath := func(path string, err error) (string, error) {
+		if err != nil || path == "" {
+			return "", err
+		}
+		path = filepath.Join(g.providerExamplesDir(), path)
+		if !fileExists(path) {
+			return "", nil
+		}
+		if rel, err := filepath.Rel(g.providerDir, path); err == nil {
+			return rel, nil
+		}
+		return path, nil
+	}
+
+	data := &docTemplateData{
+		ProviderName:      providerName,
+		ProviderShortName: providerShortName(providerName),
+
+		providerSchema: providerSchema.ConfigSchema,
+		schemaOptions:  g.schemaOptions,
+	}
+	if providerSchema.ConfigSchema != nil && providerSchema.ConfigSchema.Block != nil {
+		data.Description = providerSchema.ConfigSchema.Block.Description
+	}
+
+	var err error
+	data.ExampleFile, err = examplePath(g.paths.examplesProviderFile.Render(g.providerDir, providerName))
+	if err != nil {
+		return nil, err
+	}
+	data.HasExample = data.ExampleFile != ""
+
+	resources := func(typeName string, schemas map[string]*tfjson.Schema, exampleFile resourceFileTemplate, importFile *resourceFileTemplate) ([]*docTemplateResource, error) {
+		result := []*docTemplateResource{}
+		for _, name := range sortedSchemaNames(schemas) {
+			var err error
+			schema := schemas[name]
+			r := &docTemplateResource{
+				Type:        typeName,
+				Name:        name,
+				ShortName:   resourceShortName(name, providerName),
+				Subcategory: g.subcategory(typeName, name),
+
+				schema:        schema,
+				schemaOptions: g.schemaOptionsFor(typeName),
 			}
-		case "resources/":
-			resName := shortName + "_" + removeAllExt(relFile)
-			resSchema, ok := providerSchema.ResourceSchemas[resName]
-			if ok {
-				tmpl := resourceTemplate(tmplData)
-				render, err := tmpl.Render(resName, providerName, "Resource", "", "", resSchema)
-				if err != nil {
-					return fmt.Errorf("unable to render resource template %q: %w", rel, err)
-				}
-				_, err = out.WriteString(render)
-				if err != nil {
-					return fmt.Errorf("unable to write regindered string: %w", err)
-				}
-				return nil
+			if schema.Block != nil {
+				r.Description = schema.Block.Description
+				r.Deprecated = schema.Block.Deprecated
 			}
-		case "": // provider
-			if relFile == "index.md.tmpl" {
-				tmpl := providerTemplate(tmplData)
-				render, err := tmpl.Render(providerName, "", providerSchema.ConfigSchema)
-				if err != nil {
-					return fmt.Errorf("unable to render provider template %q: %w", rel, err)
-				}
-				_, err = out.WriteString(render)
+
+			r.ExampleFile, err = examplePath(exampleFile.Render(g.providerDir, name, providerName))
+			if err != nil {
+				return nil, err
+			}
+			r.HasExample = r.ExampleFile != ""
+
+			if importFile != nil {
+				r.ImportFile, err = examplePath(importFile.Render(g.providerDir, name, providerName))
 				if err != nil {
-					return fmt.Errorf("unable to write rendered string: %w", err)
+					return nil, err
 				}
-				return nil
+				r.HasImport = r.ImportFile != ""
 			}
+
+			result = append(result, r)
 		}
+		return result, nil
+	}
 
-		tmpl := docTemplate(tmplData)
-		err = tmpl.Render(out)
+	data.Resources, err = resources("Resource", providerSchema.ResourceSchemas, g.paths.examplesResourceFile, &g.paths.examplesResourceImport)
+	if err != nil {
+		return nil, err
+	}
+
+	data.DataSources, err = resources("Data Source", providerSchema.DataSourceSchemas, g.paths.examplesDataSourceFile, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// subcategory returns the configured subcategory of a resource or data source.
+func (g *generator) subcategory(typeName, name string) string {
+	if typeName == "Data Source" {
+		return g.config.dataSourceSubcategory(name)
+	}
+	return g.config.resourceSubcategory(name)
+}
+
+// schemaOptionsFor returns the options used to render the schema of a resource
+// or data source.
+func (g *generator) schemaOptionsFor(typeName string) schemamd.Options {
+	if typeName == "Data Source" {
+		return g.dataSourceSchemaOptions
+	}
+	return g.schemaOptions
+}
+
+// schemaFuncs returns the schema template functions of the provider schema,
+// rendering schemas with the generator's options.
+func (g *generator) schemaFuncs(providerSchema *tfjson.ProviderSchema) template.FuncMap {
+	return schemaFuncs(providerSchema, g.schemaOptions, g.dataSourceSchemaOptions)
+}
+
+type templateKind int
+
+const (
+	templateKindOther templateKind = iota
+	templateKindFallback
+	templateKindResource
+	templateKindDataSource
+	templateKindProvider
+)
+
+// templateTargets maps website source dir relative, slash separated, template
+// paths to the resource, data source or provider they render, based on the
+// configured path templates.
+type templateTargets struct {
+	resources   map[string]string
+	dataSources map[string]string
+	provider    string
+	fallbacks   map[string]bool
+}
+
+func (g *generator) templateTargets(providerSchema *tfjson.ProviderSchema) (*templateTargets, error) {
+	t := &templateTargets{
+		resources:   map[string]string{},
+		dataSources: map[string]string{},
+		fallbacks:   map[string]bool{},
+	}
+
+	for name := range providerSchema.ResourceSchemas {
+		rel, err := g.paths.websiteResourceFile.Render(g.providerDir, name, g.providerName)
 		if err != nil {
-			return fmt.Errorf("unable to render template %q: %w", rel, err)
+			return nil, fmt.Errorf("unable to render path for resource %q: %w", name, err)
 		}
-		return nil
-	})
+		t.resources[rel] = name
+	}
+
+	for name := range providerSchema.DataSourceSchemas {
+		rel, err := g.paths.websiteDataSourceFile.Render(g.providerDir, name, g.providerName)
+		if err != nil {
+			return nil, fmt.Errorf("unable to render path for data source %q: %w", name, err)
+		}
+		t.dataSources[rel] = name
+	}
+
+	for _, fallback := range []resourceFileTemplate{g.paths.websiteResourceFallbackFile, g.paths.websiteDataSourceFallbackFile} {
+		rel, err := fallback.Render(g.providerDir, "", g.providerName)
+		if err != nil {
+			return nil, fmt.Errorf("unable to render fallback template path: %w", err)
+		}
+		t.fallbacks[rel] = true
+	}
+
+	rel, err := g.paths.websiteProviderFile.Render(g.providerDir, g.providerName)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("unable to render path for provider %q: %w", g.providerName, err)
 	}
+	t.provider = rel
 
-	return nil
+	return t, nil
+}
+
+// lookup returns what the template at rel renders. Templates not matching the
+// configured paths are matched by directory and file name, as long as the
+// resource or data source exists in the schema.
+func (t *templateTargets) lookup(rel, providerName string, providerSchema *tfjson.ProviderSchema) (templateKind, string) {
+	switch {
+	case t.fallbacks[rel]:
+		return templateKindFallback, ""
+	case rel == t.provider:
+		return templateKindProvider, providerName
+	}
+
+	if name, ok := t.resources[rel]; ok {
+		return templateKindResource, name
+	}
+	if name, ok := t.dataSources[rel]; ok {
+		return templateKindDataSource, name
+	}
+
+	relDir, relFile := path.Split(rel)
+	resName := providerShortName(providerName) + "_" + removeAllExt(relFile)
+	switch relDir {
+	case "data-sources/":
+		if _, ok := providerSchema.DataSourceSchemas[resName]; ok {
+			return templateKindDataSource, resName
+		}
+	case "resources/":
+		if _, ok := providerSchema.ResourceSchemas[resName]; ok {
+			return templateKindResource, resName
+		}
+	}
+
+	return templateKindOther, ""
+}
+
+// providerSchema loads the provider schema from the providers schema file if
+// one was given, otherwise the schema is exported from Terraform.
+func (g *generator) providerSchema(ctx context.Context, providerName string) (*tfjson.ProviderSchema, error) {
+	if path := g.providersSchemaFile(); path != "" {
+		g.infof("loading schema from %q", path)
+		return loadProviderSchema(path, g.providerSource)
+	}
+
+	g.infof("exporting schema from Terraform")
+	return g.terraformProviderSchema(ctx, providerName)
+}
+
+// loadProviderSchema reads a pre-exported `terraform providers schema -json`
+// document from disk and returns the schema for the provider.
+func loadProviderSchema(path string, source providerSource) (*tfjson.ProviderSchema, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read providers schema file %q: %w", path, err)
+	}
+
+	var schemas tfjson.ProviderSchemas
+	err = json.Unmarshal(data, &schemas)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse providers schema file %q: %w", path, err)
+	}
+
+	return findProviderSchema(&schemas, source)
+}
+
+func findProviderSchema(schemas *tfjson.ProviderSchemas, source providerSource) (*tfjson.ProviderSchema, error) {
+	if ps, ok := schemas.Schemas[source.typeName]; ok {
+		return ps, nil
+	}
+
+	if ps, ok := schemas.Schemas[source.String()]; ok {
+		return ps, nil
+	}
+
+	addresses := make([]string, 0, len(schemas.Schemas))
+	for address := range schemas.Schemas {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	if !source.defaulted {
+		return nil, fmt.Errorf("unable to find schema in JSON for provider %q, available providers: %s", source, strings.Join(addresses, ", "))
+	}
+
+	// fall back to a single provider of the same type in another namespace,
+	// for example in a schema exported for a partner provider without
+	// passing the provider source
+	var match *tfjson.ProviderSchema
+	for _, address := range addresses {
+		if !strings.HasSuffix(address, "/"+source.typeName) {
+			continue
+		}
+		if match != nil {
+			return nil, fmt.Errorf("multiple schemas in JSON match provider %q, set the provider source to select one", source.typeName)
+		}
+		match = schemas.Schemas[address]
+	}
+	if match != nil {
+		return match, nil
+	}
+
+	return nil, fmt.Errorf("unable to find schema in JSON for provider %q, available providers: %s", source, strings.Join(addresses, ", "))
 }
 
 func (g *generator) terraformProviderSchema(ctx context.Context, providerName string) (*tfjson.ProviderSchema, error) {
@@ -483,13 +1091,14 @@ func (g *generator) terraformProviderSchema(ctx context.Context, providerName st
 	// fmt.Printf("[DEBUG] tmpdir %q\n", tmpDir)
 
 	g.infof("compiling provider %q", shortName)
-	providerPath := fmt.Sprintf("plugins/registry.terraform.io/hashicorp/%s/0.0.1/%s_%s", shortName, runtime.GOOS, runtime.GOARCH)
+	providerPath := fmt.Sprintf("plugins/%s/0.0.1/%s_%s", g.providerSource, runtime.GOOS, runtime.GOARCH)
 	outFile := filepath.Join(tmpDir, providerPath, fmt.Sprintf("terraform-provider-%s", shortName))
 	switch runtime.GOOS {
 	case "windows":
 		outFile = outFile + ".exe"
 	}
 	buildCmd := exec.Command("go", "build", "-o", outFile)
+	buildCmd.Dir = g.providerDir
 	// TODO: constrain env here to make it a little safer?
 	_, err = runCmd(buildCmd)
 	if err != nil {
@@ -497,9 +1106,17 @@ func (g *generator) terraformProviderSchema(ctx context.Context, providerName st
 	}
 
 	err = writeFile(filepath.Join(tmpDir, "provider.tf"), fmt.Sprintf(`
+terraform {
+  required_providers {
+    %[1]s = {
+      source = %[2]q
+    }
+  }
+}
+
 provider %[1]q {
 }
-`, shortName))
+`, shortName, g.providerSource))
 	if err != nil {
 		return nil, err
 	}
@@ -550,13 +1167,5 @@ provider %[1]q {
 		return nil, err
 	}
 
-	if ps, ok := schemas.Schemas[shortName]; ok {
-		return ps, nil
-	}
-
-	if ps, ok := schemas.Schemas["registry.terraform.io/hashicorp/"+shortName]; ok {
-		return ps, nil
-	}
-
-	return nil, fmt.Errorf("unable to find schema in JSON for provider %q", shortName)
+	return findProviderSchema(schemas, g.providerSource)
 }
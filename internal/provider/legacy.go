@@ -0,0 +1,289 @@
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// legacyCategories are the docs categories in the order the legacy
+// terraform.io website displays them in the navigation.
+var legacyCategories = []docsCategory{
+	{dir: "guides", title: "Guides"},
+	{dir: "data-sources", title: "Data Sources"},
+	{dir: "resources", title: "Resources"},
+}
+
+// legacyCategoryDirs maps the docs categories to the directories used by the
+// legacy terraform.io website.
+var legacyCategoryDirs = map[string]string{
+	"guides":       "guides",
+	"data-sources": "d",
+	"resources":    "r",
+}
+
+// legacySidebarPath returns the path of the legacy website navigation file.
+func legacySidebarPath(providerDir, providerShortName string) string {
+	return filepath.Join(providerDir, "website", providerShortName+".erb")
+}
+
+// legacyDocsURL returns the URL of a page on the legacy terraform.io website.
+func legacyDocsURL(providerShortName string, p *docsPage) string {
+	if p.category == "" {
+		return path.Join("/docs/providers", providerShortName, "index.html")
+	}
+	return path.Join("/docs/providers", providerShortName, legacyCategoryDirs[p.category], p.name+".html")
+}
+
+type legacySidebarGroup struct {
+	Title string
+	Links []navLink
+}
+
+type legacySidebarSection struct {
+	Title  string
+	Groups []legacySidebarGroup
+}
+
+// renderLegacySidebar renders the website/<provider>.erb navigation file of
+// the legacy terraform.io website from the frontmatter of the rendered pages.
+func (g *generator) renderLegacySidebar() error {
+	shortName := providerShortName(g.providerName)
+
+	pages, err := loadDocsPages(g.renderedDocsDir())
+	if err != nil {
+		return fmt.Errorf("unable to load rendered pages: %w", err)
+	}
+
+	link := func(p *docsPage) navLink {
+		return navLink{
+			Title: p.NavTitle(shortName),
+			URL:   legacyDocsURL(shortName, p),
+		}
+	}
+
+	groups := groupDocsPages(pages, shortName)
+
+	categoryGroups := func(sc string, categories []docsCategory) []legacySidebarGroup {
+		result := []legacySidebarGroup{}
+		for _, c := range categories {
+			categoryPages := groups.pages[sc][c.dir]
+			if len(categoryPages) == 0 {
+				continue
+			}
+
+			group := legacySidebarGroup{Title: c.title}
+			for _, p := range categoryPages {
+				group.Links = append(group.Links, link(p))
+			}
+			result = append(result, group)
+		}
+		return result
+	}
+
+	sections := []legacySidebarSection{}
+	for _, sc := range groups.subcategories {
+		if sc == "" {
+			continue
+		}
+		sections = append(sections, legacySidebarSection{
+			Title:  sc,
+			Groups: categoryGroups(sc, legacyCategories),
+		})
+	}
+
+	data := struct {
+		ProviderShortName string
+		ProviderTitle     string
+		IndexURL          string
+
+		// Guides, Sections and Uncategorized are listed in this order, pages
+		// without a subcategory are listed at the top level of the navigation
+		Guides        []legacySidebarGroup
+		Sections      []legacySidebarSection
+		Uncategorized []legacySidebarGroup
+	}{
+		ProviderShortName: shortName,
+		ProviderTitle:     shortName,
+		IndexURL:          legacyDocsURL(shortName, &docsPage{}),
+
+		Guides:        categoryGroups("", legacyCategories[:1]),
+		Sections:      sections,
+		Uncategorized: categoryGroups("", legacyCategories[1:]),
+	}
+	if groups.index != nil && groups.index.title != "" {
+		data.ProviderTitle = groups.index.title
+	}
+
+	var buf bytes.Buffer
+	err = legacySidebarTemplate.Execute(&buf, data)
+	if err != nil {
+		return fmt.Errorf("unable to render legacy sidebar: %w", err)
+	}
+
+	outPath := g.renderedLegacySidebarPath()
+	err = os.MkdirAll(filepath.Dir(outPath), 0755)
+	if err != nil {
+		return err
+	}
+
+	g.infof("writing legacy sidebar %q", outPath)
+	return ioutil.WriteFile(outPath, buf.Bytes(), 0644)
+}
+
+var legacySidebarTemplate = template.Must(template.New("sidebar").Parse(`<% wrap_layout :inner do %>
+  <% content_for :sidebar do %>
+    <div class="docs-sidebar hidden-print affix-top" role="complementary">
+      <ul class="nav docs-sidenav">
+        <li>
+          <a href="/docs/providers/index.html">All Providers</a>
+        </li>
+
+        <li>
+          <a href="{{ .IndexURL }}">{{ .ProviderTitle }}</a>
+        </li>
+{{- range .Guides }}
+{{ template "group" . }}
+{{- end }}
+{{- range .Sections }}
+
+        <li>
+          <a href="#">{{ .Title }}</a>
+          <ul class="nav">
+{{- range .Groups }}
+            <li>
+              <a href="#">{{ .Title }}</a>
+              <ul class="nav nav-auto-expand">
+{{- range .Links }}
+                <li>
+                  <a href="{{ .URL }}">{{ .Title }}</a>
+                </li>
+{{- end }}
+              </ul>
+            </li>
+{{- end }}
+          </ul>
+        </li>
+{{- end }}
+{{- range .Uncategorized }}
+{{ template "group" . }}
+{{- end }}
+      </ul>
+    </div>
+  <% end %>
+
+  <%= yield %>
+<% end %>
+{{ define "group" }}
+        <li>
+          <a href="#">{{ .Title }}</a>
+          <ul class="nav">
+{{- range .Links }}
+            <li>
+              <a href="{{ .URL }}">{{ .Title }}</a>
+            </li>
+{{- end }}
+          </ul>
+        </li>
+{{- end }}`))
+
+// legacyHrefPattern matches the links of the legacy sidebar.
+var legacyHrefPattern = regexp.MustCompile(`href="([^"]*)"`)
+
+// checkLegacySidebar checks that the sidebar in the legacy website dir links
+// every page of its docs dir, and that every link to a page of the provider
+// resolves.
+func checkLegacySidebar(providerShortName string) check {
+	return func(dir string) ([]issue, error) {
+		issues := []issue{}
+
+		sidebarPath := legacySidebarPath(filepath.Dir(dir), providerShortName)
+		content, err := ioutil.ReadFile(sidebarPath)
+		if os.IsNotExist(err) {
+			issues = append(issues, issue{
+				file:    sidebarPath,
+				message: "sidebar file is missing",
+			})
+			return issues, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// pages by URL
+		pages := map[string]string{}
+		docsDir := filepath.Join(dir, "docs")
+		err = filepath.Walk(docsDir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || !strings.HasSuffix(path, ".html.markdown") {
+				return nil
+			}
+
+			rel, err := filepath.Rel(docsDir, path)
+			if err != nil {
+				return err
+			}
+
+			p := &docsPage{name: removeAllExt(filepath.Base(rel))}
+			if relDir := filepath.ToSlash(filepath.Dir(rel)); relDir != "." {
+				for category, legacyDir := range legacyCategoryDirs {
+					if legacyDir == relDir {
+						p.category = category
+					}
+				}
+				if p.category == "" {
+					// reported by the allowed_dirs check
+					return nil
+				}
+			}
+
+			pages[legacyDocsURL(providerShortName, p)] = path
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		linked := map[string]bool{}
+		prefix := path.Join("/docs/providers", providerShortName) + "/"
+		for _, m := range legacyHrefPattern.FindAllStringSubmatch(string(content), -1) {
+			href := m[1]
+			if !strings.HasPrefix(href, prefix) {
+				continue
+			}
+			href = strings.SplitN(href, "#", 2)[0]
+			if _, ok := pages[href]; !ok {
+				issues = append(issues, issue{
+					file:    sidebarPath,
+					message: fmt.Sprintf("link %q does not resolve to a page", href),
+				})
+			}
+			linked[href] = true
+		}
+
+		urls := make([]string, 0, len(pages))
+		for url := range pages {
+			urls = append(urls, url)
+		}
+		sort.Strings(urls)
+		for _, url := range urls {
+			if !linked[url] {
+				issues = append(issues, issue{
+					file:    pages[url],
+					message: fmt.Sprintf("page is not linked in the sidebar %q", filepath.Base(sidebarPath)),
+				})
+			}
+		}
+
+		return issues, nil
+	}
+}
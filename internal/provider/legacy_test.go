@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRenderLegacySidebar(t *testing.T) {
+	providerDir := t.TempDir()
+	renderedWebsiteDir, err := filepath.Abs(filepath.Join("testdata", "legacy", "docs"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	g := &generator{
+		providerDir:        providerDir,
+		providerName:       "terraform-provider-example",
+		renderedWebsiteDir: renderedWebsiteDir,
+		ui:                 &bufferedUi{},
+	}
+	err = g.renderLegacySidebar()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	actual, err := ioutil.ReadFile(filepath.Join(providerDir, "website", "example.erb"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `<% wrap_layout :inner do %>
+  <% content_for :sidebar do %>
+    <div class="docs-sidebar hidden-print affix-top" role="complementary">
+      <ul class="nav docs-sidenav">
+        <li>
+          <a href="/docs/providers/index.html">All Providers</a>
+        </li>
+
+        <li>
+          <a href="/docs/providers/example/index.html">Example Provider</a>
+        </li>
+
+        <li>
+          <a href="#">Guides</a>
+          <ul class="nav">
+            <li>
+              <a href="/docs/providers/example/guides/getting-started.html">Getting Started</a>
+            </li>
+          </ul>
+        </li>
+
+        <li>
+          <a href="#">Things</a>
+          <ul class="nav">
+            <li>
+              <a href="#">Data Sources</a>
+              <ul class="nav nav-auto-expand">
+                <li>
+                  <a href="/docs/providers/example/d/thing.html">example_thing</a>
+                </li>
+              </ul>
+            </li>
+            <li>
+              <a href="#">Resources</a>
+              <ul class="nav nav-auto-expand">
+                <li>
+                  <a href="/docs/providers/example/r/thing.html">example_thing</a>
+                </li>
+              </ul>
+            </li>
+          </ul>
+        </li>
+
+        <li>
+          <a href="#">Resources</a>
+          <ul class="nav">
+            <li>
+              <a href="/docs/providers/example/r/widget.html">example_widget</a>
+            </li>
+          </ul>
+        </li>
+      </ul>
+    </div>
+  <% end %>
+
+  <%= yield %>
+<% end %>
+`
+	if diff := cmp.Diff(expected, string(actual)); diff != "" {
+		t.Fatalf("Unexpected sidebar (-wanted, +got): %s", diff)
+	}
+}
+
+func TestCheckLegacySidebar(t *testing.T) {
+	for _, c := range []struct {
+		name       string
+		websiteDir string
+		expected   []issue
+	}{
+		{
+			"missing sidebar",
+			filepath.Join("testdata", "legacy", "missing", "website"),
+			[]issue{
+				{file: filepath.Join("testdata", "legacy", "missing", "website", "example.erb"), message: "sidebar file is missing"},
+			},
+		},
+		{
+			"broken and missing links",
+			filepath.Join("testdata", "legacy", "website"),
+			[]issue{
+				{file: filepath.Join("testdata", "legacy", "website", "example.erb"), message: `link "/docs/providers/example/r/gone.html" does not resolve to a page`},
+				{file: filepath.Join("testdata", "legacy", "website", "docs", "d", "thing.html.markdown"), message: `page is not linked in the sidebar "example.erb"`},
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			issues, err := checkLegacySidebar("example")(c.websiteDir)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(c.expected, issues, cmp.AllowUnexported(issue{})); diff != "" {
+				t.Fatalf("Unexpected issues (-wanted, +got): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// manifestFileName is the name of the file in the rendered website dir that
+// lists the files generated by tfplugindocs. Only these files are removed
+// when they are no longer generated, other files in the dir are left as is.
+const manifestFileName = ".tfplugindocs-manifest"
+
+const manifestHeader = `# generated by https://github.com/hashicorp/terraform-plugin-docs
+# files in this directory managed by tfplugindocs, other files are left untouched
+`
+
+// readManifest returns the slash separated paths of the generated files listed
+// in the manifest of dir, and false if there is no manifest.
+func readManifest(dir string) (map[string]bool, bool, error) {
+	f, err := os.Open(filepath.Join(dir, manifestFileName))
+	switch {
+	case os.IsNotExist(err):
+		return map[string]bool{}, false, nil
+	case err != nil:
+		return nil, false, err
+	}
+	defer f.Close()
+
+	files := map[string]bool{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		files[line] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, false, fmt.Errorf("unable to read manifest: %w", err)
+	}
+
+	return files, true, nil
+}
+
+func writeManifest(dir string, files map[string]bool) error {
+	sorted := make([]string, 0, len(files))
+	for rel := range files {
+		sorted = append(sorted, rel)
+	}
+	sort.Strings(sorted)
+
+	var sb strings.Builder
+	sb.WriteString(manifestHeader)
+	for _, rel := range sorted {
+		sb.WriteString(rel)
+		sb.WriteString("\n")
+	}
+
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(dir, manifestFileName), []byte(sb.String()), 0644)
+}
+
+// removeStaleFiles removes the previously generated files that were not
+// generated again, and any directories left empty by their removal.
+func removeStaleFiles(dir string, previous, generated map[string]bool) ([]string, error) {
+	removed := []string{}
+	for rel := range previous {
+		if generated[rel] {
+			continue
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		err := os.Remove(path)
+		switch {
+		case os.IsNotExist(err):
+			continue
+		case err != nil:
+			return nil, err
+		}
+		removed = append(removed, rel)
+
+		// remove parent directories up to dir if empty
+		for parent := filepath.Dir(path); parent != dir && strings.HasPrefix(parent, dir); parent = filepath.Dir(parent) {
+			infos, err := ioutil.ReadDir(parent)
+			if err != nil || len(infos) > 0 {
+				break
+			}
+			err = os.Remove(parent)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	sort.Strings(removed)
+
+	return removed, nil
+}
@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+func TestManifest(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "docs")
+
+	files, ok, err := readManifest(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if ok || len(files) != 0 {
+		t.Fatalf("expected no manifest, got %v", files)
+	}
+
+	written := map[string]bool{
+		"index.md":                true,
+		"resources/thing.md":      true,
+		"data-sources/example.md": true,
+	}
+	err = writeManifest(dir, written)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, manifestFileName))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := manifestHeader + "data-sources/example.md\nindex.md\nresources/thing.md\n"
+	if diff := cmp.Diff(expected, string(content)); diff != "" {
+		t.Fatalf("Unexpected manifest (-wanted, +got): %s", diff)
+	}
+
+	files, ok, err = readManifest(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !ok {
+		t.Fatalf("expected manifest")
+	}
+	if diff := cmp.Diff(written, files); diff != "" {
+		t.Fatalf("Unexpected files (-wanted, +got): %s", diff)
+	}
+}
+
+func TestRemoveStaleFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, rel := range []string{
+		"index.md",
+		"resources/kept.md",
+		"resources/stale.md",
+		"guides/old/stale.md",
+		"images/unmanaged.png",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		err = ioutil.WriteFile(path, []byte(rel), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	previous := map[string]bool{
+		"index.md":             true,
+		"resources/kept.md":    true,
+		"resources/stale.md":   true,
+		"guides/old/stale.md":  true,
+		"resources/missing.md": true,
+	}
+	generated := map[string]bool{
+		"index.md":          true,
+		"resources/kept.md": true,
+		"resources/new.md":  true,
+	}
+
+	removed, err := removeStaleFiles(dir, previous, generated)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([]string{"guides/old/stale.md", "resources/stale.md"}, removed); diff != "" {
+		t.Fatalf("Unexpected removed files (-wanted, +got): %s", diff)
+	}
+
+	remaining, err := listFiles(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := map[string]bool{
+		"index.md":             true,
+		"resources/kept.md":    true,
+		"images/unmanaged.png": true,
+	}
+	if diff := cmp.Diff(expected, remaining); diff != "" {
+		t.Fatalf("Unexpected remaining files (-wanted, +got): %s", diff)
+	}
+
+	// directories left empty are removed, up to dir
+	_, err = os.Stat(filepath.Join(dir, "guides"))
+	if !os.IsNotExist(err) {
+		t.Fatalf("expected empty guides dir to be removed, got %v", err)
+	}
+	_, err = os.Stat(dir)
+	if err != nil {
+		t.Fatalf("expected dir to be kept, got %s", err)
+	}
+}
+
+func TestRenderStaticWebsiteWithoutManifest(t *testing.T) {
+	for _, c := range []struct {
+		name         string
+		initManifest bool
+		expected     map[string]bool
+	}{
+		{
+			"existing files are kept",
+			false,
+			map[string]bool{
+				manifestFileName:       true,
+				"guides/setup.md":      true,
+				"guides/removed.md":    true,
+				"images/unmanaged.png": true,
+			},
+		},
+		{
+			"existing files are removed unless generated",
+			true,
+			map[string]bool{
+				manifestFileName:  true,
+				"guides/setup.md": true,
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			providerDir := t.TempDir()
+			websiteTmpDir := t.TempDir()
+			for path, content := range map[string]string{
+				filepath.Join(websiteTmpDir, "templates", "guides", "setup.md.tmpl"): "# Setup\n",
+				filepath.Join(providerDir, "docs", "guides", "setup.md"):             "# Old Setup\n",
+				filepath.Join(providerDir, "docs", "guides", "removed.md"):           "# Removed\n",
+				filepath.Join(providerDir, "docs", "images", "unmanaged.png"):        "\x89PNG\x00",
+			} {
+				err := os.MkdirAll(filepath.Dir(path), 0755)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				err = ioutil.WriteFile(path, []byte(content), 0644)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+
+			providerSchema := &tfjson.ProviderSchema{}
+			g := &generator{
+				providerDir:        providerDir,
+				providerName:       "terraform-provider-example",
+				renderedWebsiteDir: "docs",
+				websiteSourceDir:   "templates",
+				websiteTmpDir:      websiteTmpDir,
+				paths:              defaultPathTemplates(),
+				config:             &config{},
+				mu:                 &sync.Mutex{},
+				initManifest:       c.initManifest,
+				ui:                 &bufferedUi{},
+			}
+			var err error
+			g.targets, err = g.templateTargets(providerSchema)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			err = g.renderStaticWebsite("terraform-provider-example", providerSchema)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			actual, err := listFiles(filepath.Join(providerDir, "docs"))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(c.expected, actual); diff != "" {
+				t.Fatalf("Unexpected files (-wanted, +got): %s", diff)
+			}
+
+			// the manifest only lists the generated files either way
+			manifest, _, err := readManifest(filepath.Join(providerDir, "docs"))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(map[string]bool{"guides/setup.md": true}, manifest); diff != "" {
+				t.Fatalf("Unexpected manifest (-wanted, +got): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+)
+
+// renderTask renders a single file, g is a copy of the generator whose ui
+// buffers the output of the task.
+type renderTask func(g *generator) error
+
+// bufferedUi records the output of a render task so the output of tasks
+// rendered in parallel is written in a deterministic order.
+type bufferedUi struct {
+	messages []bufferedMessage
+}
+
+type bufferedMessage struct {
+	level   string
+	message string
+}
+
+func (u *bufferedUi) Ask(string) (string, error) {
+	return "", errors.New("unable to ask for input while rendering")
+}
+
+func (u *bufferedUi) AskSecret(string) (string, error) {
+	return "", errors.New("unable to ask for input while rendering")
+}
+
+func (u *bufferedUi) Output(message string) { u.add("output", message) }
+func (u *bufferedUi) Info(message string)   { u.add("info", message) }
+func (u *bufferedUi) Error(message string)  { u.add("error", message) }
+func (u *bufferedUi) Warn(message string)   { u.add("warn", message) }
+
+func (u *bufferedUi) add(level, message string) {
+	u.messages = append(u.messages, bufferedMessage{level, message})
+}
+
+func (u *bufferedUi) flush(g *generator) {
+	for _, m := range u.messages {
+		switch m.level {
+		case "output":
+			g.ui.Output(m.message)
+		case "info":
+			g.ui.Info(m.message)
+		case "error":
+			g.ui.Error(m.message)
+		case "warn":
+			g.ui.Warn(m.message)
+		}
+	}
+}
+
+// renderParallel runs the tasks on a pool of at most g.parallelism workers.
+// The output of the tasks is written in the order of the tasks, and all tasks
+// are run even if some fail, their errors are returned together in the order
+// of the tasks. Render errors are only returned if no other errors occurred.
+func (g *generator) renderParallel(tasks []renderTask) error {
+	parallelism := g.parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	if parallelism > len(tasks) {
+		parallelism = len(tasks)
+	}
+
+	type result struct {
+		ui   *bufferedUi
+		err  error
+		done chan struct{}
+	}
+	results := make([]*result, len(tasks))
+	for i := range results {
+		results[i] = &result{
+			ui:   &bufferedUi{},
+			done: make(chan struct{}),
+		}
+	}
+
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < parallelism; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				r := results[i]
+
+				tg := *g
+				tg.ui = r.ui
+				r.err = tasks[i](&tg)
+				close(r.done)
+			}
+		}()
+	}
+
+	go func() {
+		for i := range tasks {
+			work <- i
+		}
+		close(work)
+	}()
+
+	var errs, renderErrs *multierror.Error
+	for _, r := range results {
+		<-r.done
+		r.ui.flush(g)
+		var ok bool
+		if renderErrs, ok = appendRenderErrors(renderErrs, r.err); !ok {
+			errs = multierror.Append(errs, r.err)
+		}
+	}
+	wg.Wait()
+
+	// errors other than render errors abort rendering
+	if errs != nil {
+		return errs
+	}
+	return renderErrs.ErrorOrNil()
+}
@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestRenderParallel(t *testing.T) {
+	for _, parallelism := range []int{0, 1, 3, 10} {
+		t.Run(fmt.Sprintf("parallelism %d", parallelism), func(t *testing.T) {
+			ui := &bufferedUi{}
+			g := &generator{parallelism: parallelism, ui: ui}
+
+			// later tasks finish first, the output must still follow the
+			// order of the tasks
+			tasks := []renderTask{}
+			expected := []bufferedMessage{}
+			for i := 0; i < 5; i++ {
+				i := i
+				tasks = append(tasks, func(g *generator) error {
+					time.Sleep(time.Duration(5-i) * time.Millisecond)
+					g.infof("task %d", i)
+					g.warnf("task %d done", i)
+					return nil
+				})
+				expected = append(expected,
+					bufferedMessage{"info", fmt.Sprintf("task %d", i)},
+					bufferedMessage{"warn", fmt.Sprintf("task %d done", i)},
+				)
+			}
+
+			err := g.renderParallel(tasks)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(expected, ui.messages, cmp.AllowUnexported(bufferedMessage{})); diff != "" {
+				t.Fatalf("Unexpected output (-wanted, +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestRenderParallelErrors(t *testing.T) {
+	renderFailure := func(file string) renderTask {
+		return func(*generator) error {
+			return newRenderError(file, errors.New("template: x:3: function \"foo\" not defined"))
+		}
+	}
+	failure := func(msg string) renderTask {
+		return func(*generator) error {
+			return errors.New(msg)
+		}
+	}
+	ok := func(*generator) error { return nil }
+
+	for _, c := range []struct {
+		name        string
+		tasks       []renderTask
+		expectedErr string
+	}{
+		{
+			"no tasks",
+			nil,
+			"",
+		},
+		{
+			"render errors in task order",
+			[]renderTask{renderFailure("b.md.tmpl"), ok, renderFailure("a.md.tmpl")},
+			`2 files failed to render:
+
+  b.md.tmpl:3: template: x:3: function "foo" not defined
+  a.md.tmpl:3: template: x:3: function "foo" not defined`,
+		},
+		{
+			"other errors take precedence",
+			[]renderTask{renderFailure("a.md.tmpl"), failure("disk full"), failure("permission denied")},
+			"2 errors occurred:\n\t* disk full\n\t* permission denied\n\n",
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			g := &generator{parallelism: 2, ui: &bufferedUi{}}
+			tasks := append([]renderTask{}, c.tasks...)
+
+			// every task runs, even after a failure
+			ran := make([]bool, len(tasks))
+			for i, task := range c.tasks {
+				i, task := i, task
+				tasks[i] = func(g *generator) error {
+					ran[i] = true
+					return task(g)
+				}
+			}
+
+			err := g.renderParallel(tasks)
+			for i, r := range ran {
+				if !r {
+					t.Fatalf("task %d did not run", i)
+				}
+			}
+
+			switch {
+			case c.expectedErr == "" && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case c.expectedErr != "" && err == nil:
+				t.Fatalf("expected error %q, got none", c.expectedErr)
+			case c.expectedErr != "" && err.Error() != c.expectedErr:
+				t.Fatalf("expected error %q, got %q", c.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestRenderStaticWebsiteParallel(t *testing.T) {
+	// the guide reads the schemas of the resources while their pages are
+	// rendered, which must not modify them
+	providerSchema := &tfjson.ProviderSchema{
+		ResourceSchemas: map[string]*tfjson.Schema{},
+	}
+	templates := map[string]string{
+		"guides/all.md.tmpl": "",
+	}
+	for i := 0; i < 8; i++ {
+		name := fmt.Sprintf("example_thing%d", i)
+		providerSchema.ResourceSchemas[name] = &tfjson.Schema{
+			Block: &tfjson.SchemaBlock{
+				Attributes: map[string]*tfjson.SchemaAttribute{
+					"id":   {AttributeType: cty.String, Computed: true},
+					"name": {AttributeType: cty.String, Required: true},
+				},
+			},
+		}
+		templates[fmt.Sprintf("resources/thing%d.md.tmpl", i)] = "# " + name + "\n\n{{ .SchemaMarkdown }}"
+		templates["guides/all.md.tmpl"] += fmt.Sprintf("{{ schemamarkdown %q }}{{ attributedescription %q \"id\" }}\n", name, name)
+	}
+
+	providerDir := t.TempDir()
+	websiteTmpDir := t.TempDir()
+	for rel, content := range templates {
+		path := filepath.Join(websiteTmpDir, "templates", filepath.FromSlash(rel))
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		err = ioutil.WriteFile(path, []byte(content), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	g := &generator{
+		providerDir:        providerDir,
+		providerName:       "terraform-provider-example",
+		renderedWebsiteDir: "docs",
+		websiteTmpDir:      websiteTmpDir,
+		paths:              defaultPathTemplates(),
+		config:             &config{},
+		parallelism:        4,
+		mu:                 &sync.Mutex{},
+		ui:                 &bufferedUi{},
+	}
+	var err error
+	g.targets, err = g.templateTargets(providerSchema)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	err = g.renderStaticWebsite(g.providerName, providerSchema)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	guide, err := ioutil.ReadFile(filepath.Join(providerDir, "docs", "guides", "all.md"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if n := strings.Count(string(guide), "The ID of this resource."); n != 8 {
+		t.Fatalf("expected the default id description of every resource in the guide, got %d:\n%s", n, guide)
+	}
+	for name, schema := range providerSchema.ResourceSchemas {
+		if desc := schema.Block.Attributes["id"].Description; desc != "" {
+			t.Fatalf("expected the schema of %q to be unchanged, got id description %q", name, desc)
+		}
+	}
+}
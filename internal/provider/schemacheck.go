@@ -0,0 +1,295 @@
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-docs/schemamd"
+)
+
+// checkDocsSchema checks the rendered docs dir against the provider schema:
+// every resource and data source must have a page, every page must belong to
+// a resource or data source of the schema, and generated schema sections must
+// match what would be rendered from the current schema. The pages are expected
+// where generate renders them, following the website path templates.
+func checkDocsSchema(providerDir, providerName string, providerSchema *tfjson.ProviderSchema, paths pathTemplates, schemaOptions, dataSourceSchemaOptions schemamd.Options) check {
+	return func(dir string) ([]issue, error) {
+		issues := []issue{}
+
+		// expected holds the slash separated paths of all expected pages,
+		// relative to the docs dir
+		expected := map[string]bool{}
+
+		checkKind := func(kind string, schemas map[string]*tfjson.Schema, websiteFile resourceFileTemplate, opts schemamd.Options) error {
+			for _, name := range sortedSchemaNames(schemas) {
+				rel, err := renderedPagePath(websiteFile.Render(providerDir, name, providerName))
+				if err != nil {
+					return fmt.Errorf("unable to render path of %s %q: %w", kind, name, err)
+				}
+				if rel == "" {
+					continue
+				}
+				expected[rel] = true
+
+				file := filepath.Join(dir, filepath.FromSlash(rel))
+				if !fileExists(file) {
+					issues = append(issues, issue{
+						file:    file,
+						message: fmt.Sprintf("%s %q has no doc page", kind, name),
+					})
+					continue
+				}
+
+				pageIssue, err := checkSchemaSection(file, schemas[name], opts)
+				if err != nil {
+					return err
+				}
+				if pageIssue != nil {
+					issues = append(issues, *pageIssue)
+				}
+			}
+			return nil
+		}
+
+		err := checkKind("resource", providerSchema.ResourceSchemas, paths.websiteResourceFile, schemaOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkKind("data source", providerSchema.DataSourceSchemas, paths.websiteDataSourceFile, dataSourceSchemaOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		indexRel, err := renderedPagePath(paths.websiteProviderFile.Render(providerDir, providerName))
+		if err != nil {
+			return nil, fmt.Errorf("unable to render path of the provider page: %w", err)
+		}
+		indexFile := filepath.Join(dir, filepath.FromSlash(indexRel))
+		if indexRel != "" && providerSchema.ConfigSchema != nil && fileExists(indexFile) {
+			pageIssue, err := checkSchemaSection(indexFile, providerSchema.ConfigSchema, schemaOptions)
+			if err != nil {
+				return nil, err
+			}
+			if pageIssue != nil {
+				issues = append(issues, *pageIssue)
+			}
+		}
+
+		unexpected, err := unexpectedPages(dir, expected)
+		if err != nil {
+			return nil, err
+		}
+		for _, rel := range unexpected {
+			if rel == indexRel {
+				continue
+			}
+			issues = append(issues, issue{
+				file:    filepath.Join(dir, filepath.FromSlash(rel)),
+				message: "page does not belong to a resource or data source of the provider schema",
+			})
+		}
+
+		return issues, nil
+	}
+}
+
+// renderedPagePath returns the slash separated path a website template path
+// is rendered to, relative to the docs dir.
+func renderedPagePath(tmplPath string, err error) (string, error) {
+	if err != nil || tmplPath == "" {
+		return "", err
+	}
+	return strings.TrimSuffix(filepath.ToSlash(tmplPath), ".tmpl"), nil
+}
+
+// unexpectedPages returns the sorted paths of the pages that are not
+// expected but are in a directory expected pages are rendered to, with the
+// extension of an expected page in that directory.
+func unexpectedPages(dir string, expected map[string]bool) ([]string, error) {
+	exts := map[string]map[string]bool{}
+	for rel := range expected {
+		d := path.Dir(rel)
+		if exts[d] == nil {
+			exts[d] = map[string]bool{}
+		}
+		exts[d][path.Ext(rel)] = true
+	}
+
+	unexpected := []string{}
+	for d, dirExts := range exts {
+		infos, err := ioutil.ReadDir(filepath.Join(dir, filepath.FromSlash(d)))
+		switch {
+		case os.IsNotExist(err):
+			continue
+		case err != nil:
+			return nil, err
+		}
+
+		for _, fi := range infos {
+			rel := path.Join(d, fi.Name())
+			if fi.IsDir() || !dirExts[path.Ext(rel)] || expected[rel] {
+				continue
+			}
+			unexpected = append(unexpected, rel)
+		}
+	}
+	sort.Strings(unexpected)
+
+	return unexpected, nil
+}
+
+// checkSchemaSection compares the schema section generated by tfplugindocs in
+// the page with the section rendered from the schema. Pages without a
+// generated schema section are not checked.
+func checkSchemaSection(file string, schema *tfjson.Schema, schemaOptions schemamd.Options) (*issue, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var expected bytes.Buffer
+	err = schemamd.RenderWithOptions(schema, &expected, schemaOptions)
+	if err != nil {
+		return nil, fmt.Errorf("unable to render schema for %q: %w", file, err)
+	}
+
+	level := topHeadingLevel(expected.String())
+	section, line, ok := generatedSchemaSection(string(content), countSectionHeadings(expected.String(), level), level)
+	if !ok {
+		return nil, nil
+	}
+
+	if strings.TrimSpace(section) == strings.TrimSpace(expected.String()) {
+		return nil, nil
+	}
+
+	return &issue{
+		file:    file,
+		line:    line,
+		message: "schema section is out of date with the provider schema, run tfplugindocs generate",
+	}, nil
+}
+
+// generatedSchemaSection returns the schema section following the schema
+// comment in the page, made of the given number of headings of the given level
+// or above and their content, and the line of the comment. Lines in fenced code
+// blocks, such as shell comments in examples, are not headings.
+func generatedSchemaSection(content string, headings, level int) (string, int, bool) {
+	lines := strings.Split(content, "\n")
+	levels := headingLevels(lines)
+
+	start := -1
+	for i, l := range lines {
+		if strings.TrimSpace(l) == schemaComment {
+			start = i
+			break
+		}
+	}
+	if start == -1 {
+		return "", 0, false
+	}
+
+	end := len(lines)
+	seen := 0
+	for i := start + 1; i < len(lines); i++ {
+		if isSectionHeading(levels[i], level) {
+			if seen == headings {
+				end = i
+				break
+			}
+			seen++
+		}
+	}
+
+	return strings.Join(lines[start+1:end], "\n"), start + 1, true
+}
+
+// countSectionHeadings returns the number of headings of the given level or
+// above in the Markdown.
+func countSectionHeadings(md string, level int) int {
+	n := 0
+	for _, l := range headingLevels(strings.Split(md, "\n")) {
+		if isSectionHeading(l, level) {
+			n++
+		}
+	}
+	return n
+}
+
+// topHeadingLevel returns the level of the highest heading in the Markdown, 2
+// if there are none.
+func topHeadingLevel(md string) int {
+	top := 0
+	for _, level := range headingLevels(strings.Split(md, "\n")) {
+		if level > 0 && (top == 0 || level < top) {
+			top = level
+		}
+	}
+	if top == 0 {
+		return 2
+	}
+	return top
+}
+
+func isSectionHeading(headingLevel, level int) bool {
+	return headingLevel > 0 && headingLevel <= level
+}
+
+// headingLevels returns the heading level of each line, 0 for lines that are
+// not headings or are in a fenced code block.
+func headingLevels(lines []string) []int {
+	levels := make([]int, len(lines))
+
+	fence := ""
+	for i, l := range lines {
+		if marker := codeFence(l); marker != "" {
+			switch {
+			case fence == "":
+				fence = marker
+			case marker[0] == fence[0] && len(marker) >= len(fence) && strings.TrimSpace(strings.TrimLeft(l, " ")[len(marker):]) == "":
+				// a closing fence has no info string
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			continue
+		}
+		levels[i] = headingLevel(l)
+	}
+
+	return levels
+}
+
+// codeFence returns the backtick or tilde fence opening or closing a fenced
+// code block on the line, an empty string if the line is not a fence.
+func codeFence(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 || len(trimmed) < 3 || (trimmed[0] != '`' && trimmed[0] != '~') {
+		return ""
+	}
+	n := len(trimmed) - len(strings.TrimLeft(trimmed, trimmed[:1]))
+	if n < 3 {
+		return ""
+	}
+	return trimmed[:n]
+}
+
+// headingLevel returns the level of the ATX heading on the line, 0 if the line
+// is not a heading.
+func headingLevel(line string) int {
+	level := len(line) - len(strings.TrimLeft(line, "#"))
+	if level == 0 || level > 6 || !strings.HasPrefix(line[level:], " ") {
+		return 0
+	}
+	return level
+}
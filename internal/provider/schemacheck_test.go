@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform-plugin-docs/schemamd"
+)
+
+func TestCheckDocsSchema(t *testing.T) {
+	providerSchema := &tfjson.ProviderSchema{
+		ConfigSchema: &tfjson.Schema{
+			Block: &tfjson.SchemaBlock{
+				Attributes: map[string]*tfjson.SchemaAttribute{
+					"endpoint": {AttributeType: cty.String, Optional: true, Description: "API endpoint."},
+				},
+			},
+		},
+		ResourceSchemas: map[string]*tfjson.Schema{
+			"example_thing": {
+				Block: &tfjson.SchemaBlock{
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"name": {AttributeType: cty.String, Required: true, Description: "Name of the thing."},
+						"id":   {AttributeType: cty.String, Computed: true, Description: "ID of the thing."},
+					},
+				},
+			},
+		},
+		DataSourceSchemas: map[string]*tfjson.Schema{
+			"example_thing": {
+				Block: &tfjson.SchemaBlock{
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"name": {AttributeType: cty.String, Required: true, Description: "Name of the thing."},
+					},
+				},
+			},
+		},
+	}
+
+	schemaSection := func(schema *tfjson.Schema) string {
+		var buf bytes.Buffer
+		err := schemamd.Render(schema, &buf)
+		if err != nil {
+			t.Fatalf("unable to render schema: %s", err)
+		}
+		return buf.String()
+	}
+
+	outdated := *providerSchema.ResourceSchemas["example_thing"].Block
+	outdated.Attributes = map[string]*tfjson.SchemaAttribute{
+		"name": outdated.Attributes["name"],
+	}
+
+	dir := t.TempDir()
+	writePages := func(pages map[string]string) {
+		t.Helper()
+
+		for rel, content := range pages {
+			path := filepath.Join(dir, filepath.FromSlash(rel))
+			err := os.MkdirAll(filepath.Dir(path), 0755)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			err = ioutil.WriteFile(path, []byte(content), 0644)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		}
+	}
+	writePages(map[string]string{
+		"index.md": "# Example Provider\n\n" + schemaComment + "\n" + schemaSection(providerSchema.ConfigSchema),
+		"resources/thing.md": "# example_thing\n\nIntro.\n\n" + schemaComment + "\n" + schemaSection(&tfjson.Schema{Block: &outdated}) +
+			"## Import\n\nImport is supported.\n",
+		"resources/gone.md":   "# example_gone\n",
+		"resources/notes.txt": "not a page",
+		"guides/thing.md":     "# Guide\n",
+	})
+
+	path := func(rel string) string {
+		return filepath.Join(dir, filepath.FromSlash(rel))
+	}
+
+	issues, err := checkDocsSchema(dir, "terraform-provider-example", providerSchema, defaultPathTemplates(), schemamd.Options{}, schemamd.Options{})(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []issue{
+		{file: path("resources/thing.md"), line: 5, message: "schema section is out of date with the provider schema, run tfplugindocs generate"},
+		{file: path("data-sources/thing.md"), message: `data source "example_thing" has no doc page`},
+		{file: path("resources/gone.md"), message: "page does not belong to a resource or data source of the provider schema"},
+	}
+	if diff := cmp.Diff(expected, issues, cmp.AllowUnexported(issue{})); diff != "" {
+		t.Fatalf("Unexpected issues (-wanted, +got): %s", diff)
+	}
+
+	// pages are expected where the path templates render them
+	paths := defaultPathTemplates()
+	paths.websiteDataSourceFile = "d/{{ .ShortName }}.html.md.tmpl"
+	writePages(map[string]string{
+		"d/thing.html.md": "# example_thing\n\n" + schemaComment + "\n" + schemaSection(providerSchema.DataSourceSchemas["example_thing"]),
+	})
+	issues, err = checkDocsSchema(dir, "terraform-provider-example", providerSchema, paths, schemamd.Options{}, schemamd.Options{})(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected = []issue{
+		{file: path("resources/thing.md"), line: 5, message: "schema section is out of date with the provider schema, run tfplugindocs generate"},
+		{file: path("resources/gone.md"), message: "page does not belong to a resource or data source of the provider schema"},
+	}
+	if diff := cmp.Diff(expected, issues, cmp.AllowUnexported(issue{})); diff != "" {
+		t.Fatalf("Unexpected issues with custom paths (-wanted, +got): %s", diff)
+	}
+}
+
+func TestGeneratedSchemaSection(t *testing.T) {
+	for _, c := range []struct {
+		name            string
+		content         string
+		headings        int
+		level           int
+		expectedSection string
+		expectedLine    int
+		expectedOK      bool
+	}{
+		{
+			"no schema comment",
+			"# Title\n\n## Schema\n",
+			1,
+			2,
+			"",
+			0,
+			false,
+		},
+		{
+			"section ends at the next heading of the same level",
+			"# Title\n" + schemaComment + "\n## Schema\n\n### Optional\n\n- `a`\n\n## Import\n",
+			1,
+			2,
+			"## Schema\n\n### Optional\n\n- `a`\n",
+			2,
+			true,
+		},
+		{
+			"section with several headings",
+			schemaComment + "\n## Schema\n\n## Deprecated\n\n- `a`\n# Other\n",
+			2,
+			2,
+			"## Schema\n\n## Deprecated\n\n- `a`",
+			1,
+			true,
+		},
+		{
+			"headings in fenced code blocks are not counted",
+			schemaComment + "\n## Schema\n\n```shell\n# Import by ID\nterraform import example_thing.a a\n```\n\n~~~~\n## Example\n```\n~~~~\n## Import\n",
+			1,
+			2,
+			"## Schema\n\n```shell\n# Import by ID\nterraform import example_thing.a a\n```\n\n~~~~\n## Example\n```\n~~~~",
+			1,
+			true,
+		},
+		{
+			"section until the end of the page",
+			schemaComment + "\n### Schema\n\n#### Optional\n",
+			1,
+			3,
+			"### Schema\n\n#### Optional\n",
+			1,
+			true,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			section, line, ok := generatedSchemaSection(c.content, c.headings, c.level)
+			if ok != c.expectedOK || line != c.expectedLine {
+				t.Fatalf("expected line %d (%t), got %d (%t)", c.expectedLine, c.expectedOK, line, ok)
+			}
+			if diff := cmp.Diff(c.expectedSection, section); diff != "" {
+				t.Fatalf("Unexpected section (-wanted, +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestHeadingLevel(t *testing.T) {
+	for _, c := range []struct {
+		line     string
+		expected int
+	}{
+		{"# Title", 1},
+		{"### Nested Schema for `a`", 3},
+		{"###### Six", 6},
+		{"####### Seven", 0},
+		{"#NoSpace", 0},
+		{"text # not a heading", 0},
+		{"", 0},
+	} {
+		t.Run(c.line, func(t *testing.T) {
+			if actual := headingLevel(c.line); actual != c.expected {
+				t.Fatalf("expected level %d, got %d", c.expected, actual)
+			}
+		})
+	}
+
+	md := "intro\n### Schema\n\n#### Optional\n\n### Deprecated\n"
+	if level := topHeadingLevel(md); level != 3 {
+		t.Fatalf("expected top heading level 3, got %d", level)
+	}
+	if n := countSectionHeadings(md, 3); n != 2 {
+		t.Fatalf("expected 2 section headings, got %d", n)
+	}
+	if level := topHeadingLevel("```\n# comment\n```\n### Schema\n"); level != 3 {
+		t.Fatalf("expected top heading level 3 outside of code blocks, got %d", level)
+	}
+	if level := topHeadingLevel("no headings"); level != 2 {
+		t.Fatalf("expected default top heading level 2, got %d", level)
+	}
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-docs/schemamd"
+)
+
+// dataSourcePrefix selects a data source instead of a resource in the name
+// passed to the schema template functions, as in Terraform references.
+const dataSourcePrefix = "data."
+
+// schemaFuncs returns the template functions backed by the provider schema. The
+// functions are always defined so templates parse, but fail if no schema is
+// available. Resource schemas are rendered with schemaOptions and data source
+// schemas with dataSourceSchemaOptions.
+func schemaFuncs(providerSchema *tfjson.ProviderSchema, schemaOptions, dataSourceSchemaOptions schemamd.Options) template.FuncMap {
+	return template.FuncMap{
+		"schemamarkdown": func(name string, style ...string) (string, error) {
+			schema, err := lookupSchema(providerSchema, name)
+			if err != nil {
+				return "", err
+			}
+			opts := schemaOptions
+			if strings.HasPrefix(name, dataSourcePrefix) {
+				opts = dataSourceSchemaOptions
+			}
+			opts, err = schemaOptionsWithStyle(opts, style)
+			if err != nil {
+				return "", err
+			}
+			return renderSchemaMarkdown(schema, opts)
+		},
+		"attributedescription": func(name, path string) (string, error) {
+			schema, err := lookupSchema(providerSchema, name)
+			if err != nil {
+				return "", err
+			}
+			return attributeDescription(schema.Block, path)
+		},
+	}
+}
+
+// lookupSchema returns the schema of the named resource, or data source if the
+// name is prefixed with "data.".
+func lookupSchema(providerSchema *tfjson.ProviderSchema, name string) (*tfjson.Schema, error) {
+	if providerSchema == nil {
+		return nil, fmt.Errorf("no provider schema available to look up %q", name)
+	}
+
+	if strings.HasPrefix(name, dataSourcePrefix) {
+		dsName := strings.TrimPrefix(name, dataSourcePrefix)
+		schema, ok := providerSchema.DataSourceSchemas[dsName]
+		if !ok {
+			return nil, fmt.Errorf("data source %q not found in the provider schema", dsName)
+		}
+		return schema, nil
+	}
+
+	schema, ok := providerSchema.ResourceSchemas[name]
+	if !ok {
+		return nil, fmt.Errorf("resource %q not found in the provider schema, prefix data sources with %q", name, dataSourcePrefix)
+	}
+	return schema, nil
+}
+
+// attributeDescription returns the description of the attribute or nested
+// block at the dot separated path, for example "rule.action.type".
+func attributeDescription(block *tfjson.SchemaBlock, path string) (string, error) {
+	if block == nil {
+		return "", fmt.Errorf("attribute %q not found, schema has no block", path)
+	}
+
+	parts := strings.Split(path, ".")
+	name, rest := parts[0], parts[1:]
+
+	if att, ok := block.Attributes[name]; ok {
+		for len(rest) > 0 {
+			if att.AttributeNestedType == nil {
+				return "", fmt.Errorf("attribute %q not found, %q has no nested attributes", path, name)
+			}
+			nested, ok := att.AttributeNestedType.Attributes[rest[0]]
+			if !ok {
+				return "", fmt.Errorf("attribute %q not found", path)
+			}
+			name, rest, att = rest[0], rest[1:], nested
+		}
+		return att.Description, nil
+	}
+
+	if nested, ok := block.NestedBlocks[name]; ok {
+		if len(rest) == 0 {
+			if nested.Block == nil {
+				return "", nil
+			}
+			return nested.Block.Description, nil
+		}
+		desc, err := attributeDescription(nested.Block, strings.Join(rest, "."))
+		if err != nil {
+			return "", fmt.Errorf("attribute %q not found", path)
+		}
+		return desc, nil
+	}
+
+	return "", fmt.Errorf("attribute %q not found", path)
+}
@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/hashicorp/terraform-plugin-docs/schemamd"
+)
+
+func TestLookupSchema(t *testing.T) {
+	resource := &tfjson.Schema{Block: &tfjson.SchemaBlock{}}
+	dataSource := &tfjson.Schema{Block: &tfjson.SchemaBlock{}}
+	providerSchema := &tfjson.ProviderSchema{
+		ResourceSchemas: map[string]*tfjson.Schema{
+			"example_thing": resource,
+		},
+		DataSourceSchemas: map[string]*tfjson.Schema{
+			"example_thing": dataSource,
+		},
+	}
+
+	for _, c := range []struct {
+		name           string
+		providerSchema *tfjson.ProviderSchema
+		expected       *tfjson.Schema
+		expectedErr    string
+	}{
+		{"example_thing", providerSchema, resource, ""},
+		{"data.example_thing", providerSchema, dataSource, ""},
+		{"example_other", providerSchema, nil, `resource "example_other" not found in the provider schema, prefix data sources with "data."`},
+		{"data.example_other", providerSchema, nil, `data source "example_other" not found in the provider schema`},
+		{"example_thing", nil, nil, `no provider schema available to look up "example_thing"`},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := lookupSchema(c.providerSchema, c.name)
+			switch {
+			case c.expectedErr == "" && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case c.expectedErr != "" && err == nil:
+				t.Fatalf("expected error %q, got none", c.expectedErr)
+			case c.expectedErr != "" && err.Error() != c.expectedErr:
+				t.Fatalf("expected error %q, got %q", c.expectedErr, err)
+			}
+
+			if actual != c.expected {
+				t.Fatalf("expected schema %p, got %p", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAttributeDescription(t *testing.T) {
+	block := &tfjson.SchemaBlock{
+		Attributes: map[string]*tfjson.SchemaAttribute{
+			"name": {AttributeType: cty.String, Description: "Name of the thing."},
+			"config": {
+				Description: "Configuration of the thing.",
+				AttributeNestedType: &tfjson.SchemaNestedAttributeType{
+					NestingMode: tfjson.SchemaNestingModeSingle,
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"port": {AttributeType: cty.Number, Description: "Port to listen on."},
+					},
+				},
+			},
+		},
+		NestedBlocks: map[string]*tfjson.SchemaBlockType{
+			"rule": {
+				NestingMode: tfjson.SchemaNestingModeList,
+				Block: &tfjson.SchemaBlock{
+					Description: "A rule.",
+					NestedBlocks: map[string]*tfjson.SchemaBlockType{
+						"action": {
+							NestingMode: tfjson.SchemaNestingModeSingle,
+							Block: &tfjson.SchemaBlock{
+								Attributes: map[string]*tfjson.SchemaAttribute{
+									"type": {AttributeType: cty.String, Description: "Type of the action."},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, c := range []struct {
+		path        string
+		expected    string
+		expectedErr string
+	}{
+		{"name", "Name of the thing.", ""},
+		{"config", "Configuration of the thing.", ""},
+		{"config.port", "Port to listen on.", ""},
+		{"rule", "A rule.", ""},
+		{"rule.action", "", ""},
+		{"rule.action.type", "Type of the action.", ""},
+		{"other", "", `attribute "other" not found`},
+		{"name.first", "", `attribute "name.first" not found, "name" has no nested attributes`},
+		{"config.host", "", `attribute "config.host" not found`},
+		{"rule.action.other", "", `attribute "rule.action.other" not found`},
+	} {
+		t.Run(c.path, func(t *testing.T) {
+			actual, err := attributeDescription(block, c.path)
+			switch {
+			case c.expectedErr == "" && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case c.expectedErr != "" && err == nil:
+				t.Fatalf("expected error %q, got none", c.expectedErr)
+			case c.expectedErr != "" && err.Error() != c.expectedErr:
+				t.Fatalf("expected error %q, got %q", c.expectedErr, err)
+			}
+
+			if actual != c.expected {
+				t.Fatalf("expected description %q, got %q", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSchemaFuncsSchemaMarkdown(t *testing.T) {
+	schema := &tfjson.Schema{
+		Block: &tfjson.SchemaBlock{
+			Attributes: map[string]*tfjson.SchemaAttribute{
+				"name": {AttributeType: cty.String, Required: true, Description: "Name of the thing."},
+				"id":   {AttributeType: cty.String, Computed: true, Description: "ID of the thing."},
+			},
+		},
+	}
+	providerSchema := &tfjson.ProviderSchema{
+		ResourceSchemas:   map[string]*tfjson.Schema{"example_thing": schema},
+		DataSourceSchemas: map[string]*tfjson.Schema{"example_thing": schema},
+	}
+
+	// data sources are rendered with the data source options
+	funcs := schemaFuncs(providerSchema, schemamd.Options{}, schemamd.Options{
+		Groups: []schemamd.Group{schemamd.GroupRequired},
+	})
+	schemaMarkdown := funcs["schemamarkdown"].(func(string, ...string) (string, error))
+
+	for _, c := range []struct {
+		name        string
+		style       []string
+		expected    string
+		expectedErr string
+	}{
+		{
+			"example_thing",
+			nil,
+			"## Schema\n\n### Required\n\n- `name` (String) Name of the thing.\n\n### Read-Only\n\n- `id` (String) ID of the thing.\n\n",
+			"",
+		},
+		{
+			"data.example_thing",
+			nil,
+			"## Schema\n\n### Required\n\n- `name` (String) Name of the thing.\n\n",
+			"",
+		},
+		{
+			"example_thing",
+			[]string{"default", "table"},
+			"",
+			"expected at most one schema style, got 2",
+		},
+		{
+			"example_other",
+			nil,
+			"",
+			`resource "example_other" not found in the provider schema, prefix data sources with "data."`,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := schemaMarkdown(c.name, c.style...)
+			switch {
+			case c.expectedErr == "" && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case c.expectedErr != "" && err == nil:
+				t.Fatalf("expected error %q, got none", c.expectedErr)
+			case c.expectedErr != "" && err.Error() != c.expectedErr:
+				t.Fatalf("expected error %q, got %q", c.expectedErr, err)
+			}
+
+			if actual != c.expected {
+				t.Fatalf("expected %q, got %q", c.expected, actual)
+			}
+		})
+	}
+}
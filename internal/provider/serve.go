@@ -0,0 +1,330 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/russross/blackfriday"
+)
+
+type server struct {
+	providerName string
+	docsDir      string
+
+	// mu guards the pages loaded from the docs dir and the snapshot of the
+	// docs dir they were loaded from
+	mu       sync.Mutex
+	pages    []*docsPage
+	snapshot fileSnapshot
+
+	ui cli.Ui
+}
+
+func (s *server) infof(format string, a ...interface{}) {
+	s.ui.Info(fmt.Sprintf(format, a...))
+}
+
+func (s *server) warnf(format string, a ...interface{}) {
+	s.ui.Warn(fmt.Sprintf(format, a...))
+}
+
+func Serve(ui cli.Ui, providerDir, providerName, renderedWebsiteDir, configPath, address string) error {
+	providerDir, err := resolveProviderDir(providerDir)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(providerDir, configPath)
+	if err != nil {
+		return err
+	}
+
+	providerName = stringOrDefault(providerName, cfg.ProviderName)
+	if providerName == "" {
+		providerName = filepath.Base(providerDir)
+	}
+
+	renderedWebsiteDir = stringOrDefault(renderedWebsiteDir, cfg.RenderedWebsiteDir, "docs")
+
+	s := &server{
+		providerName: providerName,
+		docsDir:      resolvePath(providerDir, renderedWebsiteDir),
+
+		ui: ui,
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	return s.Serve(ctx, address)
+}
+
+func (s *server) Serve(ctx context.Context, address string) error {
+	info, err := os.Stat(s.docsDir)
+	if err != nil {
+		return fmt.Errorf("unable to read rendered website dir: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("rendered website path is not a directory: %s", s.docsDir)
+	}
+
+	srv := &http.Server{
+		Addr:    address,
+		Handler: s,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+
+	s.infof("serving docs for provider %q from %q at http://%s/docs", s.providerName, s.docsDir, address)
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	s.infof("shutting down server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return srv.Shutdown(shutdownCtx)
+}
+
+type navGroup struct {
+	Title string
+	Links []navLink
+}
+
+type navLink struct {
+	Title  string
+	URL    string
+	Active bool
+}
+
+type navSection struct {
+	Title  string
+	Groups []navGroup
+}
+
+// navigation groups pages the way the Terraform Registry does: guides first,
+// then one group per subcategory containing its resources and data sources,
+// then resources and data sources without a subcategory.
+func (s *server) navigation(pages []*docsPage, current *docsPage) []navSection {
+	shortName := providerShortName(s.providerName)
+
+	link := func(p *docsPage) navLink {
+		return navLink{
+			Title:  p.NavTitle(shortName),
+			URL:    p.URL(),
+			Active: p == current,
+		}
+	}
+
+	groups := groupDocsPages(pages, shortName)
+
+	sections := []navSection{}
+	if groups.index != nil {
+		sections = append(sections, navSection{
+			Groups: []navGroup{{Links: []navLink{link(groups.index)}}},
+		})
+	}
+
+	// guides without a subcategory are listed before all subcategories
+	if guides := groups.pages[""]["guides"]; len(guides) > 0 {
+		sections = append(sections, navSection{
+			Groups: []navGroup{categoryNavGroup(docsCategories[0], guides, link)},
+		})
+	}
+
+	for _, sc := range groups.subcategories {
+		section := navSection{Title: sc}
+		for _, c := range docsCategories {
+			categoryPages := groups.pages[sc][c.dir]
+			if len(categoryPages) == 0 || (sc == "" && c.dir == "guides") {
+				continue
+			}
+
+			section.Groups = append(section.Groups, categoryNavGroup(c, categoryPages, link))
+		}
+		if len(section.Groups) > 0 {
+			sections = append(sections, section)
+		}
+	}
+
+	return sections
+}
+
+func categoryNavGroup(c docsCategory, pages []*docsPage, link func(*docsPage) navLink) navGroup {
+	group := navGroup{Title: c.title}
+	for _, p := range pages {
+		group.Links = append(group.Links, link(p))
+	}
+	return group
+}
+
+// loadPages returns the pages of the rendered website. The docs dir is polled
+// on each request, and the frontmatter of the pages is only read again if a
+// file was added, modified or removed, so changes to the rendered website are
+// picked up without restarting the server. The body of the requested page is
+// always read from disk.
+func (s *server) loadPages() ([]*docsPage, error) {
+	snapshot := fileSnapshot{}
+	err := takeSnapshot(snapshot, s.docsDir, func(string) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pages != nil && len(changedFiles(s.snapshot, snapshot)) == 0 {
+		return s.pages, nil
+	}
+
+	pages, err := loadDocsPages(s.docsDir)
+	if err != nil {
+		return nil, err
+	}
+	s.pages = pages
+	s.snapshot = snapshot
+
+	return pages, nil
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	urlPath := strings.TrimSuffix(r.URL.Path, "/")
+	if urlPath == "" {
+		http.Redirect(w, r, "/docs", http.StatusFound)
+		return
+	}
+
+	pages, err := s.loadPages()
+	if err != nil {
+		s.warnf("unable to load pages: %s", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var current *docsPage
+	for _, p := range pages {
+		if p.URL() == urlPath {
+			current = p
+			break
+		}
+	}
+	if current == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	content, err := ioutil.ReadFile(current.file)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	_, body, err := splitFrontmatter(string(content))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	warnings := []string{}
+	if current.title == "" {
+		warnings = append(warnings, "page_title is missing from the frontmatter")
+	}
+	if current.description == "" {
+		warnings = append(warnings, "description is missing from the frontmatter")
+	}
+
+	title := current.title
+	if title == "" {
+		title = current.NavTitle(providerShortName(s.providerName))
+	}
+
+	data := struct {
+		ProviderName string
+		Title        string
+		Subcategory  string
+		Description  string
+		Warnings     []string
+		Navigation   []navSection
+		Content      template.HTML
+	}{
+		ProviderName: providerShortName(s.providerName),
+		Title:        title,
+		Subcategory:  current.subcategory,
+		Description:  current.description,
+		Warnings:     warnings,
+		Navigation:   s.navigation(pages, current),
+		Content:      template.HTML(blackfriday.MarkdownCommon([]byte(body))),
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err = servePageTemplate.Execute(w, data)
+	if err != nil {
+		s.warnf("unable to render page %q: %s", current.file, err)
+	}
+}
+
+var servePageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }} | {{ .ProviderName }} provider preview</title>
+<meta name="description" content="{{ .Description }}">
+<style>
+body { margin: 0; font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; color: #1f2124; }
+nav { position: fixed; top: 0; bottom: 0; left: 0; width: 300px; overflow-y: auto; padding: 16px; box-sizing: border-box; background: #f7f8fa; border-right: 1px solid #dce0e6; font-size: 14px; }
+nav h2 { font-size: 12px; text-transform: uppercase; color: #6f7682; margin: 20px 0 4px; }
+nav h3 { font-size: 14px; margin: 12px 0 4px; }
+nav ul { list-style: none; margin: 0; padding: 0 0 0 8px; }
+nav li { margin: 2px 0; }
+nav a { color: #1563ff; text-decoration: none; }
+nav a.active { font-weight: bold; color: #1f2124; }
+main { margin-left: 300px; padding: 24px 48px; max-width: 900px; }
+.subcategory { color: #6f7682; font-size: 14px; }
+.warning { background: #fcf0e8; border: 1px solid #f5ac7a; padding: 8px 12px; margin-bottom: 8px; }
+pre { background: #f7f8fa; padding: 12px; overflow-x: auto; }
+code { font-family: SFMono-Regular, Consolas, Menlo, monospace; font-size: 90%; }
+</style>
+</head>
+<body>
+<nav>
+{{- range .Navigation }}
+{{- if .Title }}<h2>{{ .Title }}</h2>{{ end }}
+{{- range .Groups }}
+{{- if .Title }}<h3>{{ .Title }}</h3>{{ end }}
+<ul>
+{{- range .Links }}
+<li><a href="{{ .URL }}"{{ if .Active }} class="active"{{ end }}>{{ .Title }}</a></li>
+{{- end }}
+</ul>
+{{- end }}
+{{- end }}
+</nav>
+<main>
+{{- range .Warnings }}
+<div class="warning">{{ . }}</div>
+{{- end }}
+{{- if .Subcategory }}
+<div class="subcategory">{{ .Subcategory }}</div>
+{{- end }}
+{{ .Content }}
+</main>
+</body>
+</html>
+`))
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestServeHTTP(t *testing.T) {
+	s := &server{
+		providerName: "terraform-provider-example",
+		docsDir:      filepath.Join("testdata", "serve", "docs"),
+		ui:           &bufferedUi{},
+	}
+
+	for _, c := range []struct {
+		path             string
+		expectedStatus   int
+		expectedLocation string
+		expectedContent  []string
+	}{
+		{
+			"/",
+			http.StatusFound,
+			"/docs",
+			nil,
+		},
+		{
+			"/docs",
+			http.StatusOK,
+			"",
+			[]string{
+				`<title>Provider: Example | example provider preview</title>`,
+				`<meta name="description" content="The Example provider manages things.">`,
+				`<li><a href="/docs" class="active">Provider: Example</a></li>`,
+				`<h1>Example Provider</h1>`,
+			},
+		},
+		{
+			"/docs/resources/thing/",
+			http.StatusOK,
+			"",
+			[]string{
+				`<div class="subcategory">Things</div>`,
+				`<li><a href="/docs/resources/thing" class="active">example_thing</a></li>`,
+				`<p>Manages a <em>thing</em>.</p>`,
+			},
+		},
+		{
+			"/docs/data-sources/thing",
+			http.StatusOK,
+			"",
+			[]string{
+				`<title>example_thing | example provider preview</title>`,
+				`<div class="warning">page_title is missing from the frontmatter</div>`,
+				`<div class="warning">description is missing from the frontmatter</div>`,
+			},
+		},
+		{
+			"/docs/resources/other",
+			http.StatusNotFound,
+			"",
+			nil,
+		},
+	} {
+		t.Run(c.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, c.path, nil))
+
+			if rec.Code != c.expectedStatus {
+				t.Fatalf("expected status %d, got %d", c.expectedStatus, rec.Code)
+			}
+			if location := rec.Header().Get("Location"); location != c.expectedLocation {
+				t.Fatalf("expected location %q, got %q", c.expectedLocation, location)
+			}
+			for _, expected := range c.expectedContent {
+				if !strings.Contains(rec.Body.String(), expected) {
+					t.Fatalf("expected page to contain %q:\n%s", expected, rec.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestServerNavigation(t *testing.T) {
+	s := &server{
+		providerName: "terraform-provider-example",
+		docsDir:      filepath.Join("testdata", "serve", "docs"),
+	}
+
+	pages, err := s.loadPages()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var current *docsPage
+	for _, p := range pages {
+		if p.URL() == "/docs/guides/getting-started" {
+			current = p
+		}
+	}
+
+	expected := []navSection{
+		{Groups: []navGroup{{Links: []navLink{{Title: "Provider: Example", URL: "/docs"}}}}},
+		{Groups: []navGroup{{Title: "Guides", Links: []navLink{{Title: "Getting Started", URL: "/docs/guides/getting-started", Active: true}}}}},
+		{Title: "Things", Groups: []navGroup{{Title: "Resources", Links: []navLink{{Title: "example_thing", URL: "/docs/resources/thing"}}}}},
+		{Groups: []navGroup{{Title: "Data Sources", Links: []navLink{{Title: "example_thing", URL: "/docs/data-sources/thing"}}}}},
+	}
+	if diff := cmp.Diff(expected, s.navigation(pages, current)); diff != "" {
+		t.Fatalf("Unexpected navigation (-wanted, +got): %s", diff)
+	}
+}
+
+func TestServerLoadPages(t *testing.T) {
+	docsDir := t.TempDir()
+	indexPath := filepath.Join(docsDir, "index.md")
+	err := ioutil.WriteFile(indexPath, []byte("---\npage_title: Before\n---\n"), 0644)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	s := &server{docsDir: docsDir}
+
+	first, err := s.loadPages()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	second, err := s.loadPages()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(first) != 1 || &first[0] != &second[0] {
+		t.Fatalf("expected unchanged pages to be loaded once")
+	}
+
+	err = ioutil.WriteFile(indexPath, []byte("---\npage_title: After the change\n---\n"), 0644)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// the size differs, the modification time may not on coarse file systems
+	err = os.Chtimes(indexPath, time.Now(), time.Now().Add(time.Second))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	third, err := s.loadPages()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(third) != 1 || third[0].title != "After the change" {
+		t.Fatalf("expected changed page to be loaded again, got %+v", third)
+	}
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	defaultProviderHostname  = "registry.terraform.io"
+	defaultProviderNamespace = "hashicorp"
+)
+
+// providerSource is the fully qualified address of a provider, as used in the
+// source argument of a required_providers entry.
+type providerSource struct {
+	hostname  string
+	namespace string
+	typeName  string
+
+	// defaulted is set if the address was not passed and defaults to the
+	// hashicorp namespace
+	defaulted bool
+}
+
+func (s providerSource) String() string {
+	return s.hostname + "/" + s.namespace + "/" + s.typeName
+}
+
+// parseProviderSource parses a provider source address in the form
+// [<hostname>/]<namespace>/<type>. An empty address defaults to the hashicorp
+// namespace on the public registry with the given type.
+func parseProviderSource(source, defaultTypeName string) (providerSource, error) {
+	if source == "" {
+		return providerSource{
+			hostname:  defaultProviderHostname,
+			namespace: defaultProviderNamespace,
+			typeName:  defaultTypeName,
+			defaulted: true,
+		}, nil
+	}
+
+	parts := strings.Split(source, "/")
+	for _, p := range parts {
+		if p == "" {
+			return providerSource{}, fmt.Errorf("invalid provider source %q: empty part", source)
+		}
+	}
+
+	switch len(parts) {
+	case 2:
+		return providerSource{
+			hostname:  defaultProviderHostname,
+			namespace: strings.ToLower(parts[0]),
+			typeName:  strings.ToLower(parts[1]),
+		}, nil
+	case 3:
+		return providerSource{
+			hostname:  strings.ToLower(parts[0]),
+			namespace: strings.ToLower(parts[1]),
+			typeName:  strings.ToLower(parts[2]),
+		}, nil
+	}
+
+	return providerSource{}, fmt.Errorf("invalid provider source %q: expected [<hostname>/]<namespace>/<type>", source)
+}
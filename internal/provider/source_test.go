@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+func TestParseProviderSource(t *testing.T) {
+	for _, c := range []struct {
+		source      string
+		expected    string
+		expectedErr string
+	}{
+		{"", "registry.terraform.io/hashicorp/example", ""},
+		{"examplecorp/example", "registry.terraform.io/examplecorp/example", ""},
+		{"ExampleCorp/Example", "registry.terraform.io/examplecorp/example", ""},
+		{"example.com/examplecorp/example", "example.com/examplecorp/example", ""},
+		{"example", "", `invalid provider source "example": expected [<hostname>/]<namespace>/<type>`},
+		{"a/b/c/d", "", `invalid provider source "a/b/c/d": expected [<hostname>/]<namespace>/<type>`},
+		{"examplecorp/", "", `invalid provider source "examplecorp/": empty part`},
+		{"example.com//example", "", `invalid provider source "example.com//example": empty part`},
+	} {
+		t.Run(c.source, func(t *testing.T) {
+			actual, err := parseProviderSource(c.source, "example")
+			switch {
+			case c.expectedErr == "" && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case c.expectedErr != "" && err == nil:
+				t.Fatalf("expected error %q, got none", c.expectedErr)
+			case c.expectedErr != "" && err.Error() != c.expectedErr:
+				t.Fatalf("expected error %q, got %q", c.expectedErr, err)
+			}
+
+			if c.expectedErr == "" && actual.String() != c.expected {
+				t.Fatalf("expected %q, got %q", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestFindProviderSchema(t *testing.T) {
+	hashicorp := &tfjson.ProviderSchema{}
+	partner := &tfjson.ProviderSchema{}
+	other := &tfjson.ProviderSchema{}
+
+	for _, c := range []struct {
+		name        string
+		schemas     map[string]*tfjson.ProviderSchema
+		source      string
+		expected    *tfjson.ProviderSchema
+		expectedErr string
+	}{
+		{
+			"default source",
+			map[string]*tfjson.ProviderSchema{
+				"registry.terraform.io/hashicorp/example": hashicorp,
+				"registry.terraform.io/examplecorp/other": other,
+			},
+			"",
+			hashicorp,
+			"",
+		},
+		{
+			"legacy type name key",
+			map[string]*tfjson.ProviderSchema{
+				"example": hashicorp,
+			},
+			"examplecorp/example",
+			hashicorp,
+			"",
+		},
+		{
+			"explicit source",
+			map[string]*tfjson.ProviderSchema{
+				"registry.terraform.io/hashicorp/example":   hashicorp,
+				"registry.terraform.io/examplecorp/example": partner,
+			},
+			"examplecorp/example",
+			partner,
+			"",
+		},
+		{
+			"default source falls back to another namespace",
+			map[string]*tfjson.ProviderSchema{
+				"registry.terraform.io/examplecorp/example": partner,
+				"registry.terraform.io/examplecorp/other":   other,
+			},
+			"",
+			partner,
+			"",
+		},
+		{
+			"default source matches several namespaces",
+			map[string]*tfjson.ProviderSchema{
+				"registry.terraform.io/examplecorp/example": partner,
+				"example.com/examplecorp/example":           other,
+			},
+			"",
+			nil,
+			`multiple schemas in JSON match provider "example", set the provider source to select one`,
+		},
+		{
+			"explicit source does not fall back",
+			map[string]*tfjson.ProviderSchema{
+				"registry.terraform.io/hashicorp/example": hashicorp,
+				"registry.terraform.io/examplecorp/other": other,
+			},
+			"examplecorp/example",
+			nil,
+			`unable to find schema in JSON for provider "registry.terraform.io/examplecorp/example", available providers: registry.terraform.io/examplecorp/other, registry.terraform.io/hashicorp/example`,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			source, err := parseProviderSource(c.source, "example")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			actual, err := findProviderSchema(&tfjson.ProviderSchemas{Schemas: c.schemas}, source)
+			switch {
+			case c.expectedErr == "" && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case c.expectedErr != "" && err == nil:
+				t.Fatalf("expected error %q, got none", c.expectedErr)
+			case c.expectedErr != "" && err.Error() != c.expectedErr:
+				t.Fatalf("expected error %q, got %q", c.expectedErr, err)
+			}
+
+			if actual != c.expected {
+				t.Fatalf("expected schema %p, got %p", c.expected, actual)
+			}
+		})
+	}
+}
@@ -28,19 +28,29 @@ type (
 	docTemplate string
 )
 
-func newTemplate(name, text string) (*template.Template, error) {
+// newTemplate parses the template text, file paths passed to template
+// functions are relative to providerDir unless absolute. The schema template
+// functions, see schemaFuncs, are added to the template if not nil.
+func newTemplate(providerDir, name, text string, funcs template.FuncMap) (*template.Template, error) {
 	tmpl := template.New(name)
 
+	codeFile := func(format, file string) (string, error) {
+		return tmplfuncs.CodeFile(format, resolvePath(providerDir, file))
+	}
+
 	tmpl.Funcs(template.FuncMap(map[string]interface{}{
-		"codefile":      tmplfuncs.CodeFile,
+		"codefile":      codeFile,
 		"plainmarkdown": mdplain.PlainMarkdown,
 		"prefixlines":   tmplfuncs.PrefixLines,
 		"tffile": func(file string) (string, error) {
 			// TODO: omit comment handling
-			return tmplfuncs.CodeFile("terraform", file)
+			return codeFile("terraform", file)
 		},
 		"trimspace": strings.TrimSpace,
 	}))
+	if funcs != nil {
+		tmpl.Funcs(funcs)
+	}
 
 	var err error
 	tmpl, err = tmpl.Parse(text)
@@ -51,8 +61,8 @@ func newTemplate(name, text string) (*template.Template, error) {
 	return tmpl, nil
 }
 
-func renderTemplate(name string, text string, out io.Writer, data interface{}) error {
-	tmpl, err := newTemplate(name, text)
+func renderTemplate(providerDir, name string, text string, funcs template.FuncMap, out io.Writer, data interface{}) error {
+	tmpl, err := newTemplate(providerDir, name, text, funcs)
 	if err != nil {
 		return err
 	}
@@ -65,10 +75,10 @@ func renderTemplate(name string, text string, out io.Writer, data interface{}) e
 	return nil
 }
 
-func renderStringTemplate(name, text string, data interface{}) (string, error) {
+func renderStringTemplate(providerDir, name, text string, funcs template.FuncMap, data interface{}) (string, error) {
 	var buf bytes.Buffer
 
-	err := renderTemplate(name, text, &buf, data)
+	err := renderTemplate(providerDir, name, text, funcs, &buf, data)
 	if err != nil {
 		return "", err
 	}
@@ -76,21 +86,153 @@ func renderStringTemplate(name, text string, data interface{}) (string, error) {
 	return buf.String(), nil
 }
 
-func (t docTemplate) Render(out io.Writer) error {
+// docTemplateData is the data of templates that are not resource, data source
+// or provider templates, such as guides.
+type docTemplateData struct {
+	ProviderName      string
+	ProviderShortName string
+	Description       string
+
+	HasExample  bool
+	ExampleFile string
+
+	// Resources and DataSources are sorted by name
+	Resources   []*docTemplateResource
+	DataSources []*docTemplateResource
+
+	providerSchema *tfjson.Schema
+	schemaOptions  schemamd.Options
+}
+
+// Resource returns the resource with the given name, for example
+// {{ (.Resource "example_thing").SchemaMarkdown }}.
+func (d *docTemplateData) Resource(name string) (*docTemplateResource, error) {
+	for _, r := range d.Resources {
+		if r.Name == name {
+			return r, nil
+		}
+	}
+	return nil, fmt.Errorf("resource %q not found in the provider schema", name)
+}
+
+// DataSource returns the data source with the given name.
+func (d *docTemplateData) DataSource(name string) (*docTemplateResource, error) {
+	for _, r := range d.DataSources {
+		if r.Name == name {
+			return r, nil
+		}
+	}
+	return nil, fmt.Errorf("data source %q not found in the provider schema", name)
+}
+
+// SchemaMarkdown renders the schema of the provider configuration, in the
+// given style or the configured one.
+func (d *docTemplateData) SchemaMarkdown(style ...string) (string, error) {
+	opts, err := schemaOptionsWithStyle(d.schemaOptions, style)
+	if err != nil {
+		return "", err
+	}
+	return renderSchemaMarkdown(d.providerSchema, opts)
+}
+
+// Schema returns the structured schema of the provider configuration.
+func (d *docTemplateData) Schema() (*schemamd.Block, error) {
+	return newSchemaBlock(d.providerSchema, d.schemaOptions)
+}
+
+// docTemplateResource is a resource or data source in the data of doc
+// templates.
+type docTemplateResource struct {
+	Type        string
+	Name        string
+	ShortName   string
+	Description string
+	Subcategory string
+	Deprecated  bool
+
+	HasExample  bool
+	ExampleFile string
+
+	HasImport  bool
+	ImportFile string
+
+	schema        *tfjson.Schema
+	schemaOptions schemamd.Options
+}
+
+// SchemaMarkdown renders the schema of the resource or data source, in the
+// given style or the configured one.
+func (r *docTemplateResource) SchemaMarkdown(style ...string) (string, error) {
+	opts, err := schemaOptionsWithStyle(r.schemaOptions, style)
+	if err != nil {
+		return "", err
+	}
+	return renderSchemaMarkdown(r.schema, opts)
+}
+
+// Schema returns the structured schema of the resource or data source.
+func (r *docTemplateResource) Schema() (*schemamd.Block, error) {
+	return newSchemaBlock(r.schema, r.schemaOptions)
+}
+
+// schemaOptionsWithStyle returns the options with the style overridden by the
+// optional style argument of template functions and methods.
+func schemaOptionsWithStyle(opts schemamd.Options, style []string) (schemamd.Options, error) {
+	switch len(style) {
+	case 0:
+		return opts, nil
+	case 1:
+		s, err := schemamd.ParseStyle(style[0])
+		if err != nil {
+			return opts, err
+		}
+		opts.Style = s
+		return opts, nil
+	default:
+		return opts, fmt.Errorf("expected at most one schema style, got %d", len(style))
+	}
+}
+
+func renderSchemaMarkdown(schema *tfjson.Schema, schemaOptions schemamd.Options) (string, error) {
+	if schema == nil {
+		return "", nil
+	}
+
+	schemaBuffer := bytes.NewBuffer(nil)
+	err := schemamd.RenderWithOptions(schema, schemaBuffer, schemaOptions)
+	if err != nil {
+		return "", fmt.Errorf("unable to render schema: %w", err)
+	}
+	return schemaBuffer.String(), nil
+}
+
+func newSchemaBlock(schema *tfjson.Schema, schemaOptions schemamd.Options) (*schemamd.Block, error) {
+	if schema == nil {
+		return nil, nil
+	}
+
+	block, err := schemamd.NewBlockWithOptions(schema.Block, schemaOptions)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build schema: %w", err)
+	}
+	return block, nil
+}
+
+func (t docTemplate) Render(providerDir string, funcs template.FuncMap, out io.Writer, data *docTemplateData) error {
 	s := string(t)
 	if s == "" {
 		return nil
 	}
 
-	return renderTemplate("docTemplate", s, out, nil)
+	return renderTemplate(providerDir, "docTemplate", s, funcs, out, data)
 }
 
-func (t resourceFileTemplate) Render(name, providerName string) (string, error) {
+func (t resourceFileTemplate) Render(providerDir, name, providerName string) (string, error) {
 	s := string(t)
 	if s == "" {
 		return "", nil
 	}
-	return renderStringTemplate("resourceFileTemplate", s, struct {
+	return renderStringTemplate(providerDir, "resourceFileTemplate", s, nil, struct {
 		Name      string
 		ShortName string
 
@@ -105,32 +247,38 @@ func (t resourceFileTemplate) Render(name, providerName string) (string, error)
 	})
 }
 
-func (t providerFileTemplate) Render(name string) (string, error) {
+func (t providerFileTemplate) Render(providerDir, name string) (string, error) {
 	s := string(t)
 	if s == "" {
 		return "", nil
 	}
-	return renderStringTemplate("providerFileTemplate", s, struct {
+	return renderStringTemplate(providerDir, "providerFileTemplate", s, nil, struct {
 		Name      string
 		ShortName string
 	}{name, providerShortName(name)})
 }
 
-func (t providerTemplate) Render(providerName, exampleFile string, schema *tfjson.Schema) (string, error) {
+func (t providerTemplate) Render(providerDir, providerName, exampleFile string, schema *tfjson.Schema, schemaOptions schemamd.Options, funcs template.FuncMap) (string, error) {
 	schemaBuffer := bytes.NewBuffer(nil)
-	err := schemamd.Render(schema, schemaBuffer)
+	err := schemamd.RenderWithOptions(schema, schemaBuffer, schemaOptions)
 	if err != nil {
 		return "", fmt.Errorf("unable to render schema: %w", err)
 	}
 
+	schemaBlock, err := newSchemaBlock(schema, schemaOptions)
+	if err != nil {
+		return "", err
+	}
+
 	s := string(t)
 	if s == "" {
 		return "", nil
 	}
-	return renderStringTemplate("providerTemplate", s, struct {
+	return renderStringTemplate(providerDir, "providerTemplate", s, funcs, struct {
 		Type        string
 		Name        string
 		Description string
+		Deprecated  bool
 
 		HasExample  bool
 		ExampleFile string
@@ -142,8 +290,10 @@ func (t providerTemplate) Render(providerName, exampleFile string, schema *tfjso
 		ProviderShortName string
 
 		SchemaMarkdown string
+		Schema         *schemamd.Block
 	}{
 		Description: schema.Block.Description,
+		Deprecated:  schema.Block.Deprecated,
 
 		HasExample:  exampleFile != "",
 		ExampleFile: exampleFile,
@@ -152,25 +302,49 @@ func (t providerTemplate) Render(providerName, exampleFile string, schema *tfjso
 		ProviderShortName: providerShortName(providerName),
 
 		SchemaMarkdown: schemaComment + "\n" + schemaBuffer.String(),
+		Schema:         schemaBlock,
 	})
 }
 
-func (t resourceTemplate) Render(name, providerName, typeName, exampleFile, importFile string, schema *tfjson.Schema) (string, error) {
+// resourcePage is a resource or data source page rendered by a
+// resourceTemplate.
+type resourcePage struct {
+	name         string
+	providerName string
+	typeName     string
+	subcategory  string
+	exampleFile  string
+	importFile   string
+
+	schema *tfjson.Schema
+	// schemaOptions render the schema of the page, they depend on whether the
+	// page is of a resource or a data source.
+	schemaOptions schemamd.Options
+}
+
+func (t resourceTemplate) Render(providerDir string, page resourcePage, funcs template.FuncMap) (string, error) {
 	schemaBuffer := bytes.NewBuffer(nil)
-	err := schemamd.Render(schema, schemaBuffer)
+	err := schemamd.RenderWithOptions(page.schema, schemaBuffer, page.schemaOptions)
 	if err != nil {
 		return "", fmt.Errorf("unable to render schema: %w", err)
 	}
 
+	schemaBlock, err := newSchemaBlock(page.schema, page.schemaOptions)
+	if err != nil {
+		return "", err
+	}
+
 	s := string(t)
 	if s == "" {
 		return "", nil
 	}
 
-	return renderStringTemplate("resourceTemplate", s, struct {
+	return renderStringTemplate(providerDir, "resourceTemplate", s, funcs, struct {
 		Type        string
 		Name        string
 		Description string
+		Subcategory string
+		Deprecated  bool
 
 		HasExample  bool
 		ExampleFile string
@@ -182,34 +356,42 @@ func (t resourceTemplate) Render(name, providerName, typeName, exampleFile, impo
 		ProviderShortName string
 
 		SchemaMarkdown string
+		Schema         *schemamd.Block
 	}{
-		Type:        typeName,
-		Name:        name,
-		Description: schema.Block.Description,
+		Type:        page.typeName,
+		Name:        page.name,
+		Description: page.schema.Block.Description,
+		Subcategory: page.subcategory,
+		Deprecated:  page.schema.Block.Deprecated,
 
-		HasExample:  exampleFile != "",
-		ExampleFile: exampleFile,
+		HasExample:  page.exampleFile != "",
+		ExampleFile: page.exampleFile,
 
-		HasImport:  importFile != "",
-		ImportFile: importFile,
+		HasImport:  page.importFile != "",
+		ImportFile: page.importFile,
 
-		ProviderName:      providerName,
-		ProviderShortName: providerShortName(providerName),
+		ProviderName:      page.providerName,
+		ProviderShortName: providerShortName(page.providerName),
 
 		SchemaMarkdown: schemaComment + "\n" + schemaBuffer.String(),
+		Schema:         schemaBlock,
 	})
 }
 
 const defaultResourceTemplate resourceTemplate = `---
 ` + frontmatterComment + `
-page_title: "{{.Name}} {{.Type}} - {{.ProviderName}}"
-subcategory: ""
+page_title: "{{.Name}} {{.Type}}{{ if .Deprecated }} (Deprecated){{ end }} - {{.ProviderName}}"
+subcategory: "{{.Subcategory}}"
 description: |-
 {{ .Description | plainmarkdown | trimspace | prefixlines "  " }}
 ---
 
 # {{.Name}} ({{.Type}})
 
+{{ if .Deprecated -}}
+~> **Deprecated:** ` + "`{{.Name}}`" + ` is deprecated and may be removed in a future version of the provider.
+
+{{ end -}}
 {{ .Description | trimspace }}
 
 {{ if .HasExample -}}
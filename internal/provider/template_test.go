@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestDocTemplateRender(t *testing.T) {
+	providerSchema := &tfjson.ProviderSchema{
+		ConfigSchema: &tfjson.Schema{
+			Block: &tfjson.SchemaBlock{
+				Description: "The Example provider.",
+			},
+		},
+		ResourceSchemas: map[string]*tfjson.Schema{
+			"example_thing": {
+				Block: &tfjson.SchemaBlock{
+					Description: "Manages a thing.",
+					Attributes: map[string]*tfjson.SchemaAttribute{
+						"name": {AttributeType: cty.String, Required: true, Description: "Name of the thing."},
+					},
+				},
+			},
+			"example_legacy": {
+				Block: &tfjson.SchemaBlock{
+					Description: "Manages a legacy thing.",
+					Deprecated:  true,
+				},
+			},
+		},
+		DataSourceSchemas: map[string]*tfjson.Schema{
+			"example_widget": {
+				Block: &tfjson.SchemaBlock{
+					Description: "Looks up a widget.",
+				},
+			},
+		},
+	}
+
+	g := &generator{
+		providerDir: filepath.Join("testdata", "guide"),
+		examplesDir: "examples",
+		paths:       defaultPathTemplates(),
+		config: &config{
+			Resources: []resourceConfig{{Name: "example_thing", Subcategory: "Things"}},
+		},
+	}
+	data, err := g.docTemplateData("terraform-provider-example", providerSchema)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, c := range []struct {
+		name        string
+		text        string
+		expected    string
+		expectedErr string
+	}{
+		{
+			"provider",
+			"{{ .ProviderName }} {{ .ProviderShortName }} {{ .Description }} {{ .HasExample }} {{ .ExampleFile }}",
+			"terraform-provider-example example The Example provider. true " + filepath.Join("examples", "provider", "provider.tf"),
+			"",
+		},
+		{
+			"resources sorted by name",
+			"{{ range .Resources }}{{ .Type }} {{ .Name }} {{ .ShortName }} {{ .Subcategory }} {{ .Deprecated }} {{ .HasExample }} {{ .HasImport }}\n{{ end }}",
+			"Resource example_legacy legacy  true false false\nResource example_thing thing Things false true true\n",
+			"",
+		},
+		{
+			"data sources",
+			"{{ range .DataSources }}{{ .Type }} {{ .Name }} {{ .Description }} {{ .ExampleFile }} {{ .HasImport }}{{ end }}",
+			"Data Source example_widget Looks up a widget. " + filepath.Join("examples", "data-sources", "example_widget", "data-source.tf") + " false",
+			"",
+		},
+		{
+			"resource by name",
+			`{{ with .Resource "example_thing" }}{{ .ImportFile }}{{ end }}`,
+			filepath.Join("examples", "resources", "example_thing", "import.sh"),
+			"",
+		},
+		{
+			"example file of a resource",
+			`{{ tffile (.Resource "example_thing").ExampleFile }}`,
+			"```terraform\nresource \"example_thing\" \"example\" {\n  name = \"example\"\n}\n```",
+			"",
+		},
+		{
+			"schema of a resource",
+			`{{ (.Resource "example_thing").SchemaMarkdown }}`,
+			"## Schema\n\n### Required\n\n- `name` (String) Name of the thing.\n\n",
+			"",
+		},
+		{
+			"unknown resource",
+			`{{ (.Resource "example_other").Name }}`,
+			"",
+			`resource "example_other" not found in the provider schema`,
+		},
+		{
+			"unknown data source",
+			`{{ (.DataSource "example_thing").Name }}`,
+			"",
+			`data source "example_thing" not found in the provider schema`,
+		},
+		{
+			"unknown schema style",
+			`{{ .SchemaMarkdown "fancy" }}`,
+			"",
+			`unknown schema style "fancy"`,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := docTemplate(c.text).Render(g.providerDir, g.schemaFuncs(providerSchema), &out, data)
+			switch {
+			case c.expectedErr == "" && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case c.expectedErr != "" && err == nil:
+				t.Fatalf("expected error %q, got none", c.expectedErr)
+			case c.expectedErr != "":
+				if !strings.Contains(err.Error(), c.expectedErr) {
+					t.Fatalf("expected error containing %q, got %q", c.expectedErr, err)
+				}
+				return
+			}
+
+			if diff := cmp.Diff(c.expected, out.String()); diff != "" {
+				t.Fatalf("Unexpected rendered template (-wanted, +got): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1 @@
+resource "example_thing" {
@@ -0,0 +1,5 @@
+data "example_thing" "ok" {
+  name = "ok"
+
+  provisioner "local-exec" {}
+}
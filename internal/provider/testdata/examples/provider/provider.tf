@@ -0,0 +1,4 @@
+provider "example" {
+  endpoint = "https://example.com"
+  alias    = "other"
+}
@@ -0,0 +1 @@
+terraform import example_thing.ok ok
@@ -0,0 +1,32 @@
+resource "example_thing" "ok" {
+  count = 2
+  name  = "ok"
+
+  setting {
+    value = "a"
+  }
+
+  dynamic "setting" {
+    for_each = ["b"]
+    content {
+      valu = setting.value
+    }
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+resource "example_thing" "bad" {
+  nme = "bad"
+  id  = "read-only"
+
+  settings {}
+}
+
+resource "example_other" "missing" {}
+
+resource "random_pet" "other_provider" {
+  anything = true
+}
@@ -0,0 +1 @@
+data "example_widget" "example" {}
@@ -20,6 +20,41 @@ func resourceShortName(name, providerName string) string {
 	return strings.TrimPrefix(name, psn+"_")
 }
 
+// resolvePath returns path joined to base, unless path is already absolute.
+func resolvePath(base, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(base, path)
+}
+
+// resolveProviderDir returns the absolute path of the provider directory,
+// defaulting to the current working directory.
+func resolveProviderDir(providerDir string) (string, error) {
+	if providerDir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", err
+		}
+		return wd, nil
+	}
+
+	absProviderDir, err := filepath.Abs(providerDir)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := os.Stat(absProviderDir)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("expected %q to be a directory", absProviderDir)
+	}
+
+	return absProviderDir, nil
+}
+
 func copyFile(srcPath, dstPath string, mode os.FileMode) error {
 	srcFile, err := os.Open(srcPath)
 	if err != nil {
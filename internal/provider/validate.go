@@ -1,15 +1,154 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/hashicorp/go-multierror"
+	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/mitchellh/cli"
+
+	"github.com/hashicorp/terraform-plugin-docs/schemamd"
+)
+
+// Names of the checks that can be enabled in the config file.
+const (
+	checkNameAllowedFiles      = "allowed_files"
+	checkNameAllowedDirs       = "allowed_dirs"
+	checkNameBlockedExtensions = "blocked_extensions"
+	checkNameAllowedExtensions = "allowed_extensions"
+	checkNameExamples          = "examples"
+	checkNameFrontmatter       = "frontmatter"
+	checkNameLegacySidebar     = "legacy_sidebar"
+	checkNameSchema            = "schema"
 )
 
-func Validate(ui cli.Ui) error {
+var knownChecks = map[string]bool{
+	checkNameAllowedFiles:      true,
+	checkNameAllowedDirs:       true,
+	checkNameBlockedExtensions: true,
+	checkNameAllowedExtensions: true,
+	checkNameExamples:          true,
+	checkNameFrontmatter:       true,
+	checkNameLegacySidebar:     true,
+	checkNameSchema:            true,
+}
+
+type validator struct {
+	// providerDir is the absolute path to the root provider directory
+	providerDir  string
+	providerName string
+
+	// directories relative to the provider dir, unless absolute
+	renderedWebsiteDir string
+	examplesDir        string
+	websiteSourceDir   string
+
+	// providerSchema is only available if a providers schema file is passed
+	// or checkSchema is set
+	providerSchema *tfjson.ProviderSchema
+
+	// paths are the path templates generate renders pages with, used to find
+	// the pages of resources and data sources
+	paths pathTemplates
+
+	// checkSchema enables checking the rendered docs against the schema
+	checkSchema bool
+
+	// schemaOptions are the options generated schema sections are rendered
+	// with, dataSourceSchemaOptions for data sources
+	schemaOptions           schemamd.Options
+	dataSourceSchemaOptions schemamd.Options
+
+	enabledChecks map[string]bool
+
+	ui cli.Ui
+}
+
+// ValidateOptions configures Validate. Empty directories and names fall back to
+// the .tfplugindocs.hcl config of the provider and then to the defaults.
+type ValidateOptions struct {
+	ProviderDir         string
+	ProviderName        string
+	ProviderSource      string
+	RenderedWebsiteDir  string
+	ExamplesDir         string
+	WebsiteSourceDir    string
+	TFVersion           string
+	ProvidersSchemaPath string
+	ConfigPath          string
+
+	CheckSchema bool
+}
+
+func Validate(ui cli.Ui, opts ValidateOptions) error {
+	providerDir, err := resolveProviderDir(opts.ProviderDir)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(providerDir, opts.ConfigPath)
+	if err != nil {
+		return err
+	}
+
+	providerName := stringOrDefault(opts.ProviderName, cfg.ProviderName)
+	if providerName == "" {
+		providerName = filepath.Base(providerDir)
+	}
+
+	v := &validator{
+		providerDir:  providerDir,
+		providerName: providerName,
+
+		renderedWebsiteDir: stringOrDefault(opts.RenderedWebsiteDir, cfg.RenderedWebsiteDir, "docs"),
+		examplesDir:        stringOrDefault(opts.ExamplesDir, cfg.ExamplesDir, "examples"),
+		websiteSourceDir:   stringOrDefault(opts.WebsiteSourceDir, cfg.WebsiteSourceDir, "templates"),
+
+		paths: cfg.pathTemplates(),
+
+		enabledChecks: cfg.enabledChecks(),
+		checkSchema:   opts.CheckSchema,
+
+		schemaOptions:           cfg.schemaOptions(),
+		dataSourceSchemaOptions: cfg.dataSourceSchemaOptions(),
+
+		ui: ui,
+	}
+
+	if opts.CheckSchema || opts.ProvidersSchemaPath != "" {
+		source, err := parseProviderSource(stringOrDefault(opts.ProviderSource, cfg.ProviderSource), providerShortName(providerName))
+		if err != nil {
+			return err
+		}
+		if source.typeName != providerShortName(providerName) {
+			return fmt.Errorf("provider source %q does not match provider name %q", source, providerName)
+		}
+
+		// the schema is loaded or exported the same way as for generate
+		g := &generator{
+			providerDir:         providerDir,
+			providerName:        providerName,
+			providerSource:      source,
+			tfVersion:           opts.TFVersion,
+			providersSchemaPath: opts.ProvidersSchemaPath,
+
+			ui: ui,
+		}
+		v.providerSchema, err = g.providerSchema(context.Background(), providerName)
+		if err != nil {
+			return err
+		}
+	}
+
+	return v.Validate()
+}
+
+func (v *validator) Validate() error {
 	dirExists := func(name string) bool {
 		if _, err := os.Stat(name); err != nil {
 			return false
@@ -18,67 +157,110 @@ func Validate(ui cli.Ui) error {
 		return true
 	}
 
+	templatesDir := resolvePath(v.providerDir, v.websiteSourceDir)
+	examplesDir := resolvePath(v.providerDir, v.examplesDir)
+	docsDir := resolvePath(v.providerDir, v.renderedWebsiteDir)
+	legacyWebsiteDir := filepath.Join(v.providerDir, "website")
+
+	// every check is run and the errors of all of them are reported together
+	var result *multierror.Error
+
+	ui := v.ui
 	switch {
 	default:
-		ui.Warn("no website detected, exiting")
-	case dirExists("templates"):
+		ui.Warn("no website detected")
+	case dirExists(templatesDir):
 		ui.Info("detected templates directory, running checks...")
-		err := validateTemplates(ui, "templates")
+		err := v.validateTemplates(templatesDir)
 		if err != nil {
-			return err
+			result = multierror.Append(result, err)
 		}
-		if dirExists("examples") {
-			ui.Info("detected examples directory for templates, running checks...")
-			err = validateExamples(ui, "examples")
+		if dirExists(docsDir) && v.checkSchema {
+			ui.Info("detected rendered docs directory for templates, running schema checks...")
+			err = v.validateDocsSchema(docsDir)
 			if err != nil {
-				return err
+				result = multierror.Append(result, err)
 			}
 		}
-		return err
-	case dirExists("docs"):
+	case dirExists(docsDir):
 		ui.Info("detected static docs directory, running checks")
-		return validateStaticDocs(ui, "docs")
-	case dirExists("website"):
+		err := v.validateStaticDocs(docsDir)
+		if err != nil {
+			result = multierror.Append(result, err)
+		}
+		if v.checkSchema {
+			ui.Info("running schema checks of static docs directory")
+			err = v.validateDocsSchema(docsDir)
+			if err != nil {
+				result = multierror.Append(result, err)
+			}
+		}
+	case dirExists(legacyWebsiteDir):
 		ui.Info("detected legacy website directory, running checks")
-		return validateLegacyWebsite(ui, "website")
+		err := v.validateLegacyWebsite(legacyWebsiteDir)
+		if err != nil {
+			result = multierror.Append(result, err)
+		}
 	}
 
-	return nil
+	// examples are checked whichever website layout was detected
+	if dirExists(examplesDir) && v.enabledChecks[checkNameExamples] {
+		ui.Info("detected examples directory, running checks...")
+		err := v.validateExamples(examplesDir)
+		if err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	return result.ErrorOrNil()
 }
 
-func validateExamples(ui cli.Ui, dir string) error {
+func (v *validator) validateExamples(dir string) error {
+	if v.providerSchema == nil {
+		v.ui.Info("no providers schema passed, skipping schema checks of examples")
+	}
+
+	checks := []namedCheck{
+		{checkNameExamples, checkExamples(providerShortName(v.providerName), v.providerSchema)},
+	}
+	issues, err := v.runChecks(dir, checks)
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		v.ui.Warn(fmt.Sprintf("%s: %s", issue.location(), issue.message))
+	}
+	if len(issues) > 0 {
+		return fmt.Errorf("invalid examples directory")
+	}
 	return nil
 }
 
-func validateTemplates(ui cli.Ui, dir string) error {
-	checks := []check{
-		checkAllowedFiles(
+func (v *validator) validateTemplates(dir string) error {
+	checks := []namedCheck{
+		{checkNameAllowedFiles, checkAllowedFiles(
 			"index.md",
 			"index.md.tmpl",
-		),
-		checkAllowedDirs(
+		)},
+		{checkNameAllowedDirs, checkAllowedDirs(
 			"data-sources",
 			"guides",
 			"resources",
-		),
-		checkBlockedExtensions(
+		)},
+		{checkNameBlockedExtensions, checkBlockedExtensions(
 			".html.md.tmpl",
-		),
-		checkAllowedExtensions(
+		)},
+		{checkNameAllowedExtensions, checkAllowedExtensions(
 			".md",
 			".md.tmpl",
-		),
+		)},
 	}
-	issues := []issue{}
-	for _, c := range checks {
-		checkIssues, err := c(dir)
-		if err != nil {
-			return err
-		}
-		issues = append(issues, checkIssues...)
+	issues, err := v.runChecks(dir, checks)
+	if err != nil {
+		return err
 	}
 	for _, issue := range issues {
-		ui.Warn(fmt.Sprintf("%s: %s", issue.file, issue.message))
+		v.ui.Warn(fmt.Sprintf("%s: %s", issue.location(), issue.message))
 	}
 	if len(issues) > 0 {
 		return fmt.Errorf("invalid templates directory")
@@ -86,53 +268,175 @@ func validateTemplates(ui cli.Ui, dir string) error {
 	return nil
 }
 
-func validateStaticDocs(ui cli.Ui, dir string) error {
-	checks := []check{
-		checkAllowedFiles(
+func (v *validator) validateStaticDocs(dir string) error {
+	checks := []namedCheck{
+		{checkNameAllowedFiles, checkAllowedFiles(
 			"index.md",
-		),
-		checkAllowedDirs(
+			manifestFileName,
+		)},
+		{checkNameAllowedDirs, checkAllowedDirs(
 			"data-sources",
 			"guides",
 			"resources",
-		),
-		checkBlockedExtensions(
+		)},
+		{checkNameBlockedExtensions, checkBlockedExtensions(
 			".html.md.tmpl",
 			".html.md",
 			".md.tmpl",
-		),
-		checkAllowedExtensions(
+		)},
+		{checkNameAllowedExtensions, checkAllowedExtensions(
 			".md",
-		),
+		)},
+	}
+	issues, err := v.runChecks(dir, checks)
+	if err != nil {
+		return err
+	}
+
+	// files left untouched by generate, such as images, are not checked
+	managed, hasManifest, err := readManifest(dir)
+	if err != nil {
+		return err
+	}
+	if hasManifest {
+		issues = managedIssues(dir, managed, issues)
+	}
+	for _, issue := range issues {
+		v.ui.Warn(fmt.Sprintf("%s: %s", issue.location(), issue.message))
 	}
+	if len(issues) > 0 {
+		return fmt.Errorf("invalid templates directory")
+	}
+	return nil
+}
+
+// managedIssues returns the issues of the files in dir listed in the
+// manifest and of the directories containing them.
+func managedIssues(dir string, managed map[string]bool, issues []issue) []issue {
+	result := []issue{}
+	for _, i := range issues {
+		rel, err := filepath.Rel(dir, i.file)
+		if err != nil {
+			result = append(result, i)
+			continue
+		}
+		rel = filepath.ToSlash(rel)
+
+		if managed[rel] {
+			result = append(result, i)
+			continue
+		}
+		for m := range managed {
+			if strings.HasPrefix(m, rel+"/") {
+				result = append(result, i)
+				break
+			}
+		}
+	}
+	return result
+}
+
+func (v *validator) validateDocsSchema(dir string) error {
+	checks := []namedCheck{
+		{checkNameSchema, checkDocsSchema(v.providerDir, v.providerName, v.providerSchema, v.paths, v.schemaOptions, v.dataSourceSchemaOptions)},
+	}
+	issues, err := v.runChecks(dir, checks)
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		v.ui.Warn(fmt.Sprintf("%s: %s", issue.location(), issue.message))
+	}
+	if len(issues) > 0 {
+		return fmt.Errorf("rendered docs do not match the provider schema")
+	}
+	return nil
+}
+
+// runChecks runs the enabled checks against dir.
+func (v *validator) runChecks(dir string, checks []namedCheck) ([]issue, error) {
 	issues := []issue{}
 	for _, c := range checks {
-		checkIssues, err := c(dir)
+		if !v.enabledChecks[c.name] {
+			continue
+		}
+		checkIssues, err := c.check(dir)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		issues = append(issues, checkIssues...)
 	}
+	return issues, nil
+}
+
+func (v *validator) validateLegacyWebsite(dir string) error {
+	docsDir := filepath.Join(dir, "docs")
+	if _, err := os.Stat(docsDir); err != nil {
+		return fmt.Errorf("unable to read legacy docs directory: %w", err)
+	}
+
+	docsChecks := []namedCheck{
+		{checkNameAllowedFiles, checkAllowedFiles(
+			"index.html.markdown",
+		)},
+		{checkNameAllowedDirs, checkAllowedDirs(
+			"d",
+			"guides",
+			"r",
+		)},
+		{checkNameAllowedExtensions, checkAllowedExtensions(
+			".html.markdown",
+		)},
+		{checkNameFrontmatter, checkRequiredFrontmatter(
+			"layout",
+			"page_title",
+			"description",
+		)},
+	}
+	issues, err := v.runChecks(docsDir, docsChecks)
+	if err != nil {
+		return err
+	}
+
+	websiteChecks := []namedCheck{
+		{checkNameLegacySidebar, checkLegacySidebar(providerShortName(v.providerName))},
+	}
+	websiteIssues, err := v.runChecks(dir, websiteChecks)
+	if err != nil {
+		return err
+	}
+	issues = append(issues, websiteIssues...)
+
 	for _, issue := range issues {
-		ui.Warn(fmt.Sprintf("%s: %s", issue.file, issue.message))
+		v.ui.Warn(fmt.Sprintf("%s: %s", issue.location(), issue.message))
 	}
 	if len(issues) > 0 {
-		return fmt.Errorf("invalid templates directory")
+		return fmt.Errorf("invalid legacy website directory")
 	}
 	return nil
 }
 
-func validateLegacyWebsite(ui cli.Ui, dir string) error {
-	panic("not implemented")
-}
-
 type issue struct {
-	file    string
+	file string
+	// line is optional, 0 if the issue applies to the whole file
+	line    int
 	message string
 }
 
+func (i issue) location() string {
+	if i.line > 0 {
+		return fmt.Sprintf("%s:%d", i.file, i.line)
+	}
+	return i.file
+}
+
 type check func(dir string) ([]issue, error)
 
+type namedCheck struct {
+	name  string
+	check check
+}
+
 func checkBlockedExtensions(exts ...string) check {
 	return func(dir string) ([]issue, error) {
 		issues := []issue{}
@@ -140,7 +444,7 @@ func checkBlockedExtensions(exts ...string) check {
 			if err != nil {
 				return err
 			}
-			if info.IsDir() {
+			if info.IsDir() || info.Name() == manifestFileName {
 				return nil
 			}
 			for _, ext := range exts {
@@ -169,7 +473,7 @@ func checkAllowedExtensions(exts ...string) check {
 			if err != nil {
 				return err
 			}
-			if info.IsDir() {
+			if info.IsDir() || info.Name() == manifestFileName {
 				return nil
 			}
 			valid := false
@@ -264,3 +568,45 @@ func checkAllowedFiles(dirs ...string) check {
 		return issues, nil
 	}
 }
+
+func checkRequiredFrontmatter(keys ...string) check {
+	return func(dir string) ([]issue, error) {
+		issues := []issue{}
+		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			fm, _, err := splitFrontmatter(string(content))
+			if err != nil {
+				issues = append(issues, issue{
+					file:    path,
+					message: fmt.Sprintf("unable to parse frontmatter: %s", err),
+				})
+				return nil
+			}
+
+			for _, key := range keys {
+				if strings.TrimSpace(fm[key]) == "" {
+					issues = append(issues, issue{
+						file:    path,
+						message: fmt.Sprintf("frontmatter %q is missing", key),
+					})
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return issues, nil
+	}
+}
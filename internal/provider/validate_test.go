@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestValidatorValidate(t *testing.T) {
+	ui := &bufferedUi{}
+	v := &validator{
+		providerDir:        filepath.Join("testdata", "validate"),
+		providerName:       "terraform-provider-example",
+		renderedWebsiteDir: "docs",
+		examplesDir:        "examples",
+		websiteSourceDir:   "templates",
+		paths:              defaultPathTemplates(),
+		enabledChecks:      knownChecks,
+		ui:                 ui,
+	}
+
+	// the examples are checked although the templates directory is invalid
+	expected := "2 errors occurred:\n\t* invalid templates directory\n\t* invalid examples directory\n\n"
+	err := v.Validate()
+	if err == nil {
+		t.Fatalf("expected error %q, got none", expected)
+	}
+	if err.Error() != expected {
+		t.Fatalf("expected error %q, got %q", expected, err)
+	}
+}
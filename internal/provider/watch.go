@@ -0,0 +1,441 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// watchInterval is how often the watched directories are polled for changes.
+const watchInterval = time.Second
+
+// fileSnapshot records the state of every file in a set of watched
+// directories, polling is used instead of OS notifications so the watch
+// behaves the same across platforms and editors that replace files on save.
+type fileSnapshot map[string]fileState
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// takeSnapshot walks dir and records every file for which include returns true.
+// Directories starting with a dot are skipped.
+func takeSnapshot(snapshot fileSnapshot, dir string, include func(path string) bool) error {
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() {
+			if path != dir && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if include(path) {
+			snapshot[path] = fileState{
+				modTime: info.ModTime(),
+				size:    info.Size(),
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// changedFiles returns the sorted paths that were added, modified or removed
+// between the two snapshots.
+func changedFiles(before, after fileSnapshot) []string {
+	changed := []string{}
+	for path, state := range after {
+		if prev, ok := before[path]; !ok || prev != state {
+			changed = append(changed, path)
+		}
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func isGoSourceFile(path string) bool {
+	switch filepath.Base(path) {
+	case "go.mod", "go.sum":
+		return true
+	}
+	return filepath.Ext(path) == ".go"
+}
+
+// isSchemaSource returns true if changes to the file require loading the
+// provider schema again.
+func (g *generator) isSchemaSource(path string) bool {
+	if schemaFile := g.providersSchemaFile(); schemaFile != "" {
+		return path == schemaFile
+	}
+	return isGoSourceFile(path)
+}
+
+func (g *generator) snapshot() (fileSnapshot, error) {
+	snapshot := fileSnapshot{}
+
+	all := func(string) bool { return true }
+
+	err := takeSnapshot(snapshot, g.providerTemplatesDir(), all)
+	if err != nil {
+		return nil, err
+	}
+
+	err = takeSnapshot(snapshot, g.providerExamplesDir(), all)
+	if err != nil {
+		return nil, err
+	}
+
+	if schemaFile := g.providersSchemaFile(); schemaFile != "" {
+		info, err := os.Stat(schemaFile)
+		if err != nil {
+			return nil, err
+		}
+		snapshot[schemaFile] = fileState{
+			modTime: info.ModTime(),
+			size:    info.Size(),
+		}
+		return snapshot, nil
+	}
+
+	vendorDir := filepath.Join(g.providerDir, "vendor") + string(filepath.Separator)
+	err = takeSnapshot(snapshot, g.providerDir, func(path string) bool {
+		return isGoSourceFile(path) && !strings.HasPrefix(path, vendorDir)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// watchChanges polls the templates, examples and Go sources (or providers
+// schema file) of the provider and re-renders the pages affected by each
+// change until ctx is cancelled. The schema is only loaded again when its
+// source changes.
+func (g *generator) watchChanges(ctx context.Context, providerSchema *tfjson.ProviderSchema) error {
+	schemaSource := "Go sources"
+	if schemaFile := g.providersSchemaFile(); schemaFile != "" {
+		schemaSource = fmt.Sprintf("%q", schemaFile)
+	}
+	g.infof("watching %q, %q and %s for changes, press Ctrl+C to stop", g.providerTemplatesDir(), g.providerExamplesDir(), schemaSource)
+
+	snapshot, err := g.snapshot()
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			g.infof("stopping watch")
+			return nil
+		case <-ticker.C:
+		}
+
+		next, err := g.snapshot()
+		if err != nil {
+			return err
+		}
+
+		changed := changedFiles(snapshot, next)
+		snapshot = next
+		if len(changed) == 0 {
+			continue
+		}
+
+		providerSchema, err = g.rebuild(ctx, providerSchema, changed)
+		if err != nil {
+			// keep watching so the error can be fixed without restarting
+			g.warnf("unable to render website: %s", err)
+		}
+	}
+}
+
+// rebuild re-renders the website for the changed files and returns the
+// provider schema, which is only loaded again if its source changed.
+func (g *generator) rebuild(ctx context.Context, providerSchema *tfjson.ProviderSchema, changed []string) (*tfjson.ProviderSchema, error) {
+	for _, path := range changed {
+		g.infof("detected change: %q", path)
+	}
+
+	for _, path := range changed {
+		if g.isSchemaSource(path) {
+			ps, err := g.providerSchema(ctx, g.providerName)
+			if err != nil {
+				return providerSchema, err
+			}
+			return ps, g.rerenderAll(ps)
+		}
+	}
+
+	resources := map[string]bool{}
+	dataSources := map[string]bool{}
+	renderProvider := false
+	files := []string{}
+
+	for _, path := range changed {
+		kind, name, ok := g.affectedPage(providerSchema, path)
+		if !ok {
+			// the change may affect any page, for example a generic
+			// template or an example file used by a guide
+			return providerSchema, g.rerenderAll(providerSchema)
+		}
+
+		switch kind {
+		case templateKindResource:
+			resources[name] = true
+		case templateKindDataSource:
+			dataSources[name] = true
+		case templateKindProvider:
+			renderProvider = true
+		default:
+			files = append(files, name)
+		}
+	}
+
+	for name := range resources {
+		err := g.rerenderResource(providerSchema, name,
+			g.paths.websiteResourceFile,
+			websiteResourceFileStatic,
+			func() error {
+				return g.renderMissingResourceDoc(g.providerName, providerSchema, name, "Resource", providerSchema.ResourceSchemas[name],
+					g.paths.websiteResourceFile,
+					g.paths.websiteResourceFallbackFile,
+					websiteResourceFileStatic,
+					g.paths.examplesResourceFile,
+					&g.paths.examplesResourceImport)
+			})
+		if err != nil {
+			return providerSchema, err
+		}
+	}
+
+	for name := range dataSources {
+		err := g.rerenderResource(providerSchema, name,
+			g.paths.websiteDataSourceFile,
+			websiteDataSourceFileStatic,
+			func() error {
+				return g.renderMissingResourceDoc(g.providerName, providerSchema, name, "Data Source", providerSchema.DataSourceSchemas[name],
+					g.paths.websiteDataSourceFile,
+					g.paths.websiteDataSourceFallbackFile,
+					websiteDataSourceFileStatic,
+					g.paths.examplesDataSourceFile,
+					nil)
+			})
+		if err != nil {
+			return providerSchema, err
+		}
+	}
+
+	if renderProvider {
+		candidates := []string{}
+		for _, t := range append([]providerFileTemplate{g.paths.websiteProviderFile}, websiteProviderFileStatic...) {
+			rel, err := t.Render(g.providerDir, g.providerName)
+			if err != nil {
+				return providerSchema, err
+			}
+			candidates = append(candidates, rel)
+		}
+		err := g.rerenderFiles(providerSchema, candidates, func() error {
+			return g.renderMissingProviderDoc(g.providerName, providerSchema, providerSchema.ConfigSchema,
+				g.paths.websiteProviderFile,
+				websiteProviderFileStatic,
+				g.paths.examplesProviderFile)
+		})
+		if err != nil {
+			return providerSchema, err
+		}
+	}
+
+	if len(files) > 0 {
+		err := g.rerenderFiles(providerSchema, files, nil)
+		if err != nil {
+			return providerSchema, err
+		}
+	}
+
+	if g.legacySidebar {
+		// titles and subcategories in the frontmatter of the re-rendered
+		// pages may have changed
+		g.infof("rendering legacy sidebar")
+		err := g.renderLegacySidebar()
+		if err != nil {
+			return providerSchema, err
+		}
+	}
+
+	return providerSchema, nil
+}
+
+// affectedPage maps a changed template or example file to the page it belongs
+// to. For templates of other pages, such as guides, the name is the path
+// relative to the template dir. If the page can not be determined, false is
+// returned.
+func (g *generator) affectedPage(providerSchema *tfjson.ProviderSchema, path string) (templateKind, string, bool) {
+	if rel, err := filepath.Rel(g.providerTemplatesDir(), path); err == nil && !strings.HasPrefix(rel, "..") {
+		rel = filepath.ToSlash(rel)
+
+		kind, name := g.targets.lookup(rel, g.providerName, providerSchema)
+		switch {
+		case kind == templateKindFallback:
+			return kind, "", false
+		case kind != templateKindOther:
+			return kind, name, true
+		case removeAllExt(rel) == removeAllExt(g.targets.provider):
+			// static provider page
+			return templateKindProvider, g.providerName, true
+		}
+
+		return templateKindOther, filepath.FromSlash(rel), true
+	}
+
+	if rel, err := filepath.Rel(g.providerExamplesDir(), path); err == nil && !strings.HasPrefix(rel, "..") {
+		dir := filepath.Dir(path)
+
+		exampleDir := func(t resourceFileTemplate, name string) bool {
+			examplePath, err := t.Render(g.providerDir, name, g.providerName)
+			if err != nil || examplePath == "" {
+				return false
+			}
+			return filepath.Dir(filepath.Join(g.providerExamplesDir(), examplePath)) == dir
+		}
+
+		for name := range providerSchema.ResourceSchemas {
+			if exampleDir(g.paths.examplesResourceFile, name) || exampleDir(g.paths.examplesResourceImport, name) {
+				return templateKindResource, name, true
+			}
+		}
+		for name := range providerSchema.DataSourceSchemas {
+			if exampleDir(g.paths.examplesDataSourceFile, name) {
+				return templateKindDataSource, name, true
+			}
+		}
+
+		examplePath, err := g.paths.examplesProviderFile.Render(g.providerDir, g.providerName)
+		if err == nil && examplePath != "" && filepath.Dir(filepath.Join(g.providerExamplesDir(), examplePath)) == dir {
+			return templateKindProvider, g.providerName, true
+		}
+	}
+
+	return templateKindOther, "", false
+}
+
+// rerenderAll renders the whole website again from the template dir using the
+// given schema.
+func (g *generator) rerenderAll(providerSchema *tfjson.ProviderSchema) error {
+	err := os.RemoveAll(g.websiteTmpDir)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(g.websiteTmpDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	err = g.copyTemplates()
+	if err != nil {
+		return err
+	}
+
+	return g.render(providerSchema)
+}
+
+func (g *generator) rerenderResource(providerSchema *tfjson.ProviderSchema, name string, websiteFileTemplate resourceFileTemplate, websiteStaticCandidateTemplates []resourceFileTemplate, renderMissing func() error) error {
+	candidates := []string{}
+	for _, t := range append([]resourceFileTemplate{websiteFileTemplate}, websiteStaticCandidateTemplates...) {
+		rel, err := t.Render(g.providerDir, name, g.providerName)
+		if err != nil {
+			return err
+		}
+		candidates = append(candidates, rel)
+	}
+
+	return g.rerenderFiles(providerSchema, candidates, renderMissing)
+}
+
+// rerenderFiles refreshes the given template dir relative files in the tmp
+// dir from the template dir, optionally generates a missing template and then
+// renders the files again. The output of a file is only replaced once it
+// rendered successfully, and only removed if its template no longer exists.
+func (g *generator) rerenderFiles(providerSchema *tfjson.ProviderSchema, rels []string, renderMissing func() error) error {
+	for _, rel := range rels {
+		srcPath := filepath.Join(g.providerTemplatesDir(), rel)
+		tmpPath := filepath.Join(g.tempTemplatesDir(), rel)
+
+		err := os.Remove(tmpPath)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		if !fileExists(srcPath) {
+			continue
+		}
+
+		err = os.MkdirAll(filepath.Dir(tmpPath), 0755)
+		if err != nil {
+			return err
+		}
+
+		info, err := os.Stat(srcPath)
+		if err != nil {
+			return err
+		}
+
+		err = copyFile(srcPath, tmpPath, info.Mode())
+		if err != nil {
+			return err
+		}
+	}
+
+	if renderMissing != nil {
+		err := renderMissing()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, rel := range rels {
+		tmpPath := filepath.Join(g.tempTemplatesDir(), rel)
+		if fileExists(tmpPath) {
+			err := g.renderTemplateFile(g.providerName, providerSchema, tmpPath)
+			if err != nil {
+				return newRenderError(g.templateFile(tmpPath), err)
+			}
+			continue
+		}
+
+		renderedRel := strings.TrimSuffix(rel, ".tmpl")
+		err := os.Remove(filepath.Join(g.renderedDocsDir(), renderedRel))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		delete(g.generated, filepath.ToSlash(renderedRel))
+	}
+
+	return writeManifest(g.renderedDocsDir(), g.generated)
+}
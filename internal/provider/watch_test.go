@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+func TestChangedFiles(t *testing.T) {
+	now := time.Now()
+	before := fileSnapshot{
+		"same":     {modTime: now, size: 1},
+		"modified": {modTime: now, size: 1},
+		"resized":  {modTime: now, size: 1},
+		"removed":  {modTime: now, size: 1},
+	}
+	after := fileSnapshot{
+		"same":     {modTime: now, size: 1},
+		"modified": {modTime: now.Add(time.Second), size: 1},
+		"resized":  {modTime: now, size: 2},
+		"added":    {modTime: now, size: 1},
+	}
+
+	expected := []string{"added", "modified", "removed", "resized"}
+	if diff := cmp.Diff(expected, changedFiles(before, after)); diff != "" {
+		t.Fatalf("Unexpected changed files (-wanted, +got): %s", diff)
+	}
+	if changed := changedFiles(after, after); len(changed) != 0 {
+		t.Fatalf("expected no changes, got %v", changed)
+	}
+}
+
+func TestAffectedPage(t *testing.T) {
+	providerDir := t.TempDir()
+	providerSchema := &tfjson.ProviderSchema{
+		ResourceSchemas: map[string]*tfjson.Schema{
+			"example_thing": {Block: &tfjson.SchemaBlock{}},
+		},
+		DataSourceSchemas: map[string]*tfjson.Schema{
+			"example_thing": {Block: &tfjson.SchemaBlock{}},
+		},
+	}
+
+	g := &generator{
+		providerDir:      providerDir,
+		providerName:     "terraform-provider-example",
+		examplesDir:      "examples",
+		websiteSourceDir: "templates",
+		paths:            defaultPathTemplates(),
+	}
+	var err error
+	g.targets, err = g.templateTargets(providerSchema)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, c := range []struct {
+		path         string
+		expectedKind templateKind
+		expectedName string
+		expectedOK   bool
+	}{
+		{"templates/resources/thing.md.tmpl", templateKindResource, "example_thing", true},
+		{"templates/data-sources/thing.md.tmpl", templateKindDataSource, "example_thing", true},
+		{"templates/index.md.tmpl", templateKindProvider, "terraform-provider-example", true},
+		// static pages are matched by file name
+		{"templates/resources/thing.md", templateKindResource, "example_thing", true},
+		{"templates/index.md", templateKindProvider, "terraform-provider-example", true},
+		// pages of resources not in the schema are rendered as they are
+		{"templates/resources/other.md.tmpl", templateKindOther, filepath.Join("resources", "other.md.tmpl"), true},
+		{"templates/guides/setup.md.tmpl", templateKindOther, filepath.Join("guides", "setup.md.tmpl"), true},
+		// fallback templates affect every page
+		{"templates/resources.md.tmpl", templateKindFallback, "", false},
+
+		{"examples/resources/example_thing/resource.tf", templateKindResource, "example_thing", true},
+		{"examples/resources/example_thing/import.sh", templateKindResource, "example_thing", true},
+		{"examples/data-sources/example_thing/data-source.tf", templateKindDataSource, "example_thing", true},
+		{"examples/provider/provider.tf", templateKindProvider, "terraform-provider-example", true},
+		// examples used by other pages, for example guides, affect every page
+		{"examples/guides/setup.tf", templateKindOther, "", false},
+
+		{"main.go", templateKindOther, "", false},
+	} {
+		t.Run(c.path, func(t *testing.T) {
+			kind, name, ok := g.affectedPage(providerSchema, filepath.Join(providerDir, filepath.FromSlash(c.path)))
+			if kind != c.expectedKind || name != c.expectedName || ok != c.expectedOK {
+				t.Fatalf("expected %d %q %t, got %d %q %t", c.expectedKind, c.expectedName, c.expectedOK, kind, name, ok)
+			}
+		})
+	}
+}
+
+func TestIsSchemaSource(t *testing.T) {
+	g := &generator{providerDir: filepath.FromSlash("/provider")}
+	for path, expected := range map[string]bool{
+		"/provider/main.go":                 true,
+		"/provider/go.mod":                  true,
+		"/provider/schema.json":             false,
+		"/provider/templates/index.md.tmpl": false,
+	} {
+		if actual := g.isSchemaSource(filepath.FromSlash(path)); actual != expected {
+			t.Fatalf("expected %t for %q without providers schema, got %t", expected, path, actual)
+		}
+	}
+
+	// a relative providers schema path is relative to the provider dir
+	g.providersSchemaPath = "schema.json"
+	for path, expected := range map[string]bool{
+		"/provider/main.go":     false,
+		"/provider/schema.json": true,
+	} {
+		if actual := g.isSchemaSource(filepath.FromSlash(path)); actual != expected {
+			t.Fatalf("expected %t for %q with providers schema, got %t", expected, path, actual)
+		}
+	}
+}
+
+func TestRerenderFiles(t *testing.T) {
+	providerDir := t.TempDir()
+	templatePath := filepath.Join(providerDir, "templates", "guides", "setup.md.tmpl")
+	renderedPath := filepath.Join(providerDir, "docs", "guides", "setup.md")
+	err := os.MkdirAll(filepath.Dir(templatePath), 0755)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	providerSchema := &tfjson.ProviderSchema{}
+	g := &generator{
+		providerDir:        providerDir,
+		providerName:       "terraform-provider-example",
+		renderedWebsiteDir: "docs",
+		websiteSourceDir:   "templates",
+		websiteTmpDir:      t.TempDir(),
+		paths:              defaultPathTemplates(),
+		config:             &config{},
+		generated:          map[string]bool{},
+		mu:                 &sync.Mutex{},
+		ui:                 &bufferedUi{},
+	}
+	g.targets, err = g.templateTargets(providerSchema)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	rerender := func(template string) error {
+		t.Helper()
+
+		if template == "" {
+			err := os.Remove(templatePath)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		} else {
+			err := ioutil.WriteFile(templatePath, []byte(template), 0644)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		}
+		return g.rerenderFiles(providerSchema, []string{filepath.Join("guides", "setup.md.tmpl")}, nil)
+	}
+	rendered := func() (string, bool) {
+		t.Helper()
+
+		data, err := ioutil.ReadFile(renderedPath)
+		if os.IsNotExist(err) {
+			return "", false
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		manifest, _, err := readManifest(filepath.Join(providerDir, "docs"))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !manifest["guides/setup.md"] {
+			t.Fatalf("expected rendered page in the manifest, got %v", manifest)
+		}
+		return string(data), true
+	}
+
+	err = rerender("# Setup\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if content, _ := rendered(); content != "# Setup\n" {
+		t.Fatalf("unexpected rendered page %q", content)
+	}
+
+	// a template that fails to render keeps the page rendered before
+	err = rerender("# Setup {{ .Foo")
+	if err == nil {
+		t.Fatalf("expected render error")
+	}
+	if content, _ := rendered(); content != "# Setup\n" {
+		t.Fatalf("expected previous page to be kept, got %q", content)
+	}
+
+	err = rerender("# Setup, fixed\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if content, _ := rendered(); content != "# Setup, fixed\n" {
+		t.Fatalf("unexpected rendered page %q", content)
+	}
+
+	// the page of a removed template is removed
+	err = rerender("")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, ok := rendered(); ok {
+		t.Fatalf("expected page of removed template to be removed")
+	}
+	if g.generated["guides/setup.md"] {
+		t.Fatalf("expected page of removed template to be removed from the manifest")
+	}
+}
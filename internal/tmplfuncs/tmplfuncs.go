@@ -3,8 +3,6 @@ package tmplfuncs
 import (
 	"fmt"
 	"io/ioutil"
-	"os"
-	"path/filepath"
 	"strings"
 )
 
@@ -12,16 +10,10 @@ func PrefixLines(prefix, text string) string {
 	return prefix + strings.Join(strings.Split(text, "\n"), "\n"+prefix)
 }
 
+// CodeFile reads the file and returns its content as a Markdown code block in
+// the given format. Callers are responsible for resolving relative paths.
 func CodeFile(format, file string) (string, error) {
-	// paths are relative to the rendering process work dir, which
-	// may be undesirable, probably need to think about it
-	wd, err := os.Getwd()
-	if err != nil {
-		return "", err
-	}
-
-	fullPath := filepath.Join(wd, file)
-	content, err := ioutil.ReadFile(fullPath)
+	content, err := ioutil.ReadFile(file)
 	if err != nil {
 		return "", fmt.Errorf("unable to read content from %q: %w", file, err)
 	}
@@ -0,0 +1,228 @@
+package schemamd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// classicSection is a top-level section of the classic style.
+type classicSection struct {
+	title       string
+	intro       string
+	nestedIntro string
+
+	// entries returns the attributes and blocks of a block listed in the section
+	entries func(b *Block) []*Attribute
+	// flags returns the flags written before the description of an entry
+	flags func(att *Attribute) []string
+	// anchorID returns the id of the anchor of the nested schema subsection
+	anchorID func(att *Attribute) string
+}
+
+var classicSections = []classicSection{
+	{
+		title:       "Argument Reference",
+		intro:       "The following arguments are supported:",
+		nestedIntro: "The `%s` %s supports the following arguments:",
+
+		entries: func(b *Block) []*Attribute {
+			args := []*Attribute{}
+			for _, att := range b.Attributes() {
+				if !att.ReadOnly {
+					args = append(args, att)
+				}
+			}
+			return args
+		},
+		flags: func(att *Attribute) []string {
+			if att.Required {
+				return []string{"Required"}
+			}
+			return []string{"Optional"}
+		},
+		anchorID: func(att *Attribute) string {
+			return att.AnchorID
+		},
+	},
+	{
+		title:       "Attributes Reference",
+		intro:       "In addition to all arguments above, the following attributes are exported:",
+		nestedIntro: "The `%s` %s exports the following attributes:",
+
+		entries: func(b *Block) []*Attribute {
+			return b.ReadOnly
+		},
+		flags: func(att *Attribute) []string {
+			return nil
+		},
+		anchorID: func(att *Attribute) string {
+			if att.ReadOnly {
+				return att.AnchorID
+			}
+			// arguments with read-only nested attributes have a subsection in
+			// both sections
+			return att.AnchorID + "--attributes"
+		},
+	},
+}
+
+// renderClassic writes the schema as "## Argument Reference" and "## Attributes
+// Reference" sections. Required and optional attributes and blocks are listed
+// as arguments, read-only ones as attributes, and nested schemas get a
+// subsection in each section they have entries in. Empty sections are omitted,
+// deprecated arguments and attributes are summarized in a first section, as
+// in the other styles. Groups left out in the options are left out of the
+// sections, for example leaving out GroupReadOnly omits the attributes section.
+func renderClassic(schema *tfjson.Schema, w io.Writer, opts Options) error {
+	block, err := NewBlockWithOptions(schema.Block, opts)
+	if err != nil {
+		return err
+	}
+
+	err = writeDeprecatedSummary(w, block, opts)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range classicSections {
+		if !s.hasEntries(block) {
+			continue
+		}
+
+		_, err = io.WriteString(w, opts.heading(0, s.title)+"\n\n")
+		if err != nil {
+			return err
+		}
+
+		err = s.writeBlock(w, block, s.intro, opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// hasEntries returns true if the block or any of its nested schemas has
+// entries in the section.
+func (s classicSection) hasEntries(b *Block) bool {
+	if len(s.entries(b)) > 0 {
+		return true
+	}
+
+	for _, att := range b.Attributes() {
+		for _, n := range att.nestedSchemas() {
+			if s.hasEntries(n.Nested) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (s classicSection) writeBlock(w io.Writer, b *Block, intro string, opts Options) error {
+	entries := s.entries(b)
+	if len(entries) > 0 {
+		_, err := io.WriteString(w, intro+"\n\n")
+		if err != nil {
+			return err
+		}
+
+		for _, att := range entries {
+			err = s.writeEntry(w, att, opts)
+			if err != nil {
+				return fmt.Errorf("unable to render %q: %w", att.Path, err)
+			}
+		}
+
+		_, err = io.WriteString(w, "\n")
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, att := range b.Attributes() {
+		for _, n := range s.nestedSchemas(att) {
+			_, err := io.WriteString(w, "<a id=\""+s.anchorID(n)+"\"></a>\n")
+			if err != nil {
+				return err
+			}
+
+			_, err = io.WriteString(w, opts.heading(1, "`"+n.Path+"`")+"\n\n")
+			if err != nil {
+				return err
+			}
+
+			if opts.NestedSummaries {
+				_, err = io.WriteString(w, n.summary(opts)+"\n\n")
+				if err != nil {
+					return err
+				}
+			}
+
+			kind := "attribute"
+			if n.IsBlock {
+				kind = "block"
+			}
+
+			err = s.writeBlock(w, n.Nested, fmt.Sprintf(s.nestedIntro, n.Path, kind), opts)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// nestedSchemas returns the nested schemas of the attribute with entries in
+// the section.
+func (s classicSection) nestedSchemas(att *Attribute) []*Attribute {
+	nested := []*Attribute{}
+	for _, n := range att.nestedSchemas() {
+		if s.hasEntries(n.Nested) {
+			nested = append(nested, n)
+		}
+	}
+	return nested
+}
+
+func (s classicSection) writeEntry(w io.Writer, att *Attribute, opts Options) error {
+	parts := []string{}
+
+	flags := s.flags(att)
+	if att.Sensitive {
+		flags = append(flags, "Sensitive")
+	}
+	if att.Deprecated {
+		flags = append(flags, "Deprecated")
+	}
+	if len(flags) > 0 {
+		parts = append(parts, "("+strings.Join(flags, ", ")+")")
+	}
+
+	desc := formatDescription(att.Description, tfjson.SchemaDescriptionKind(att.DescriptionKind), "  ", opts)
+	if desc != "" {
+		parts = append(parts, desc)
+	}
+
+	if nested := s.nestedSchemas(att); len(nested) > 0 {
+		links := []string{}
+		for _, n := range nested {
+			links = append(links, "[`"+n.Path+"`](#"+s.anchorID(n)+")")
+		}
+		parts = append(parts, "See "+strings.Join(links, ", ")+" below.")
+	}
+
+	entry := "* `" + att.Name + "`"
+	if len(parts) > 0 {
+		entry += " - " + strings.Join(parts, " ")
+	}
+
+	_, err := io.WriteString(w, entry+"\n")
+	return err
+}
@@ -0,0 +1,65 @@
+package schemamd
+
+import (
+	"io"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// DeprecatedAttributes returns the deprecated attributes and nested blocks of
+// the block and of its nested schemas, in the order they are rendered.
+func (b *Block) DeprecatedAttributes() []*Attribute {
+	deprecated := []*Attribute{}
+	for _, att := range b.Attributes() {
+		if att.Deprecated {
+			deprecated = append(deprecated, att)
+		}
+	}
+
+	for _, att := range b.Attributes() {
+		for _, n := range att.nestedSchemas() {
+			deprecated = append(deprecated, n.Nested.DeprecatedAttributes()...)
+		}
+	}
+
+	return deprecated
+}
+
+// writeDeprecatedSummary writes a section listing the deprecated attributes
+// and nested blocks with their descriptions, which usually hold the migration
+// guidance, nothing if there are none. The classic style names them arguments
+// and attributes, like its other sections, in a section of its own.
+func writeDeprecatedSummary(w io.Writer, b *Block, opts Options) error {
+	deprecated := b.DeprecatedAttributes()
+	if len(deprecated) == 0 {
+		return nil
+	}
+
+	title := opts.heading(1, "Deprecated")
+	intro := "The following attributes and blocks are deprecated and may be removed in a future version:"
+	if opts.Style == StyleClassic {
+		title = opts.heading(0, "Deprecated Arguments and Attributes")
+		intro = "The following arguments and attributes are deprecated and may be removed in a future version:"
+	}
+
+	_, err := io.WriteString(w, title+"\n\n"+intro+"\n\n")
+	if err != nil {
+		return err
+	}
+
+	for _, att := range deprecated {
+		entry := "- `" + att.Path + "`"
+		desc := formatDescription(att.Description, tfjson.SchemaDescriptionKind(att.DescriptionKind), "  ", opts)
+		if desc != "" {
+			entry += " " + desc
+		}
+
+		_, err = io.WriteString(w, entry+"\n")
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,43 @@
+package schemamd
+
+import (
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// plainEscaper escapes the characters of plain descriptions that would
+// otherwise be rendered as Markdown emphasis, code, links or HTML.
+var plainEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	`*`, `\*`,
+	`_`, `\_`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`<`, `\<`,
+)
+
+// formatDescription returns the trimmed description as Markdown to be written
+// in a list item. Descriptions of the plain kind are escaped if
+// opts.EscapePlainDescriptions is set, descriptions without a kind are left as
+// they are, and lines after the first are indented by indent so multi-line
+// descriptions stay in the list item.
+func formatDescription(desc string, kind tfjson.SchemaDescriptionKind, indent string, opts Options) string {
+	desc = strings.TrimSpace(strings.ReplaceAll(desc, "\r\n", "\n"))
+
+	if kind == tfjson.SchemaDescriptionKindPlain && opts.EscapePlainDescriptions {
+		desc = plainEscaper.Replace(desc)
+	}
+
+	lines := strings.Split(desc, "\n")
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+		if line != "" {
+			line = indent + line
+		}
+		lines[i] = line
+	}
+
+	return strings.Join(lines, "\n")
+}
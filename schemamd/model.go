@@ -0,0 +1,380 @@
+package schemamd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Block is a structured view of a schema block, for templates that lay out
+// the schema themselves instead of using the rendered Markdown. Attributes
+// and nested blocks are grouped and sorted like in the rendered Markdown.
+type Block struct {
+	Description     string
+	DescriptionKind string
+	Deprecated      bool
+
+	Required []*Attribute
+	Optional []*Attribute
+	ReadOnly []*Attribute
+
+	// order holds the indexes in groupFilters of the groups in the order they
+	// are rendered, all groups in the default order if empty.
+	order []int
+}
+
+// Attribute is an attribute, nested block or object attribute of a Block.
+type Attribute struct {
+	Name string
+
+	// Path is the dot separated path of the attribute from the root block,
+	// for example "timeouts.create".
+	Path string
+
+	// AnchorID is the id of the anchor of the nested schema section in the
+	// rendered Markdown, empty if the attribute has no nested schema.
+	AnchorID string
+
+	// Type is the friendly name of the type, for example "List of String",
+	// "Attributes Set" or "Block List".
+	Type string
+
+	Description     string
+	DescriptionKind string
+
+	Required   bool
+	Optional   bool
+	ReadOnly   bool
+	Sensitive  bool
+	Deprecated bool
+
+	// IsBlock is set for nested blocks, NestingMode, MinItems and MaxItems
+	// are set for nested blocks and nested attributes.
+	IsBlock     bool
+	NestingMode string
+	MinItems    uint64
+	MaxItems    uint64
+
+	// Nested is the schema of nested blocks, nested attributes and object
+	// types, or collections of object types, nil for other attributes.
+	Nested *Block
+
+	// Elements are the positional elements of tuple types, named by their
+	// index.
+	Elements []*Attribute
+}
+
+// Attributes returns the attributes and nested blocks of all groups, in the
+// order the groups are rendered.
+func (b *Block) Attributes() []*Attribute {
+	atts := []*Attribute{}
+	for _, i := range b.groupIndexes() {
+		atts = append(atts, b.group(i)...)
+	}
+	return atts
+}
+
+// groupIndexes returns the indexes in groupFilters of the groups of the block,
+// in the order they are rendered.
+func (b *Block) groupIndexes() []int {
+	if len(b.order) == 0 {
+		return Options{}.groupIndexes()
+	}
+	return b.order
+}
+
+// group returns the attributes of the group with index i of groupFilters.
+func (b *Block) group(i int) []*Attribute {
+	switch i {
+	case 0:
+		return b.Required
+	case 1:
+		return b.Optional
+	case 2:
+		return b.ReadOnly
+	}
+	return nil
+}
+
+// NewBlock returns the structured view of a schema block.
+func NewBlock(block *tfjson.SchemaBlock) (*Block, error) {
+	return NewBlockWithOptions(block, Options{})
+}
+
+// NewBlockWithOptions returns the structured view of a schema block, with
+// types written, object types inlined and groups left out according to the
+// options.
+func NewBlockWithOptions(block *tfjson.SchemaBlock, opts Options) (*Block, error) {
+	err := opts.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return newBlock(nil, block, opts)
+}
+
+func newBlock(parents []string, block *tfjson.SchemaBlock, opts Options) (*Block, error) {
+	b := &Block{
+		Description:     block.Description,
+		DescriptionKind: string(block.DescriptionKind),
+		Deprecated:      block.Deprecated,
+
+		order: opts.groupIndexes(),
+	}
+
+	groups, err := groupBlockChildren(block)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range b.order {
+		for _, name := range groups[i] {
+			path := appendPath(parents, name)
+
+			var att *Attribute
+			if childBlock, ok := block.NestedBlocks[name]; ok {
+				att, err = newBlockTypeAttribute(path, childBlock, opts)
+				if err != nil {
+					return nil, fmt.Errorf("unable to build block %q: %w", name, err)
+				}
+			} else if childAtt, ok := block.Attributes[name]; ok {
+				att, err = newSchemaAttribute(path, childAtt, i, opts)
+				if err != nil {
+					return nil, fmt.Errorf("unable to build attribute %q: %w", name, err)
+				}
+			} else {
+				return nil, fmt.Errorf("unexpected name in schema %q", name)
+			}
+
+			b.add(i, att)
+		}
+	}
+
+	return b, nil
+}
+
+// add adds the attribute to the group with index i of groupFilters.
+func (b *Block) add(i int, att *Attribute) {
+	att.setGroup(i)
+	switch i {
+	case 0:
+		b.Required = append(b.Required, att)
+	case 1:
+		b.Optional = append(b.Optional, att)
+	case 2:
+		b.ReadOnly = append(b.ReadOnly, att)
+	}
+}
+
+// setGroup sets the flag of the group with index i of groupFilters.
+func (a *Attribute) setGroup(i int) {
+	a.Required = i == 0
+	a.Optional = i == 1
+	a.ReadOnly = i == 2
+}
+
+func newBlockTypeAttribute(path []string, block *tfjson.SchemaBlockType, opts Options) (*Attribute, error) {
+	nested, err := newBlock(path, block.Block, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Attribute{
+		Name:     path[len(path)-1],
+		Path:     strings.Join(path, "."),
+		AnchorID: "nestedblock--" + strings.Join(path, "--"),
+		Type:     nestingTypeName("Block", block.NestingMode),
+
+		Description:     block.Block.Description,
+		DescriptionKind: string(block.Block.DescriptionKind),
+
+		Deprecated: block.Block.Deprecated,
+
+		IsBlock:     true,
+		NestingMode: string(block.NestingMode),
+		MinItems:    block.MinItems,
+		MaxItems:    block.MaxItems,
+
+		Nested: nested,
+	}, nil
+}
+
+// newSchemaAttribute builds an attribute in the group with index group of
+// groupFilters. The attributes of nested attributes and object types are in
+// the group of their parent, like in the rendered Markdown.
+func newSchemaAttribute(path []string, att *tfjson.SchemaAttribute, group int, opts Options) (*Attribute, error) {
+	name := path[len(path)-1]
+
+	a := &Attribute{
+		Name: name,
+		Path: strings.Join(path, "."),
+
+		Description:     att.Description,
+		DescriptionKind: string(att.DescriptionKind),
+
+		Sensitive:  att.Sensitive,
+		Deprecated: att.Deprecated,
+	}
+
+	if name == "id" && a.Description == "" {
+		a.Description = "The ID of this resource."
+	}
+
+	if nat := att.AttributeNestedType; nat != nil {
+		a.AnchorID = "nestedatt--" + strings.Join(path, "--")
+		a.Type = nestingTypeName("Attributes", nat.NestingMode)
+		a.NestingMode = string(nat.NestingMode)
+		a.MinItems = nat.MinItems
+		a.MaxItems = nat.MaxItems
+
+		names := []string{}
+		for n := range nat.Attributes {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+
+		a.Nested = &Block{}
+		for _, childName := range names {
+			child, err := newSchemaAttribute(appendPath(path, childName), nat.Attributes[childName], group, opts)
+			if err != nil {
+				return nil, fmt.Errorf("unable to build attribute %q: %w", childName, err)
+			}
+			a.Nested.add(group, child)
+		}
+		return a, nil
+	}
+
+	return a, a.setType("nestedatt--", path, att.AttributeType, group, opts)
+}
+
+func newTypeAttribute(anchorPrefix string, path []string, ty cty.Type, group int, opts Options) (*Attribute, error) {
+	a := &Attribute{
+		Name: path[len(path)-1],
+		Path: strings.Join(path, "."),
+	}
+
+	return a, a.setType(anchorPrefix, path, ty, group, opts)
+}
+
+// setType sets the type of the attribute, the nested schema for object types
+// and collections of object types that are not inlined, and the elements of
+// tuple types.
+func (a *Attribute) setType(anchorPrefix string, path []string, ty cty.Type, group int, opts Options) error {
+	var b strings.Builder
+	err := WriteTypeWithOptions(&b, ty, opts)
+	if err != nil {
+		return err
+	}
+	a.Type = b.String()
+
+	if ty.IsTupleType() {
+		for i, et := range ty.TupleElementTypes() {
+			elem, err := newTypeAttribute(anchorPrefix, appendPath(path, strconv.Itoa(i)), et, group, opts)
+			if err != nil {
+				return fmt.Errorf("unable to build element %d: %w", i, err)
+			}
+			elem.setGroup(group)
+			a.Elements = append(a.Elements, elem)
+		}
+		return nil
+	}
+
+	ty, ok := nestedObjectType(ty, opts)
+	if !ok {
+		return nil
+	}
+
+	a.AnchorID = anchorPrefix + strings.Join(path, "--")
+
+	atts := ty.AttributeTypes()
+	names := []string{}
+	for n := range atts {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	a.Nested = &Block{}
+	for _, name := range names {
+		child, err := newTypeAttribute("nestedobjatt--", appendPath(path, name), atts[name], group, opts)
+		if err != nil {
+			return fmt.Errorf("unable to build attribute %q: %w", name, err)
+		}
+		a.Nested.add(group, child)
+	}
+
+	return nil
+}
+
+// nestedSchemas returns the attributes with a nested schema: the attribute
+// itself, or the elements of tuple types.
+func (a *Attribute) nestedSchemas() []*Attribute {
+	if a.Nested != nil {
+		return []*Attribute{a}
+	}
+
+	nested := []*Attribute{}
+	for _, elem := range a.Elements {
+		if elem.Nested != nil {
+			nested = append(nested, elem)
+		}
+	}
+	return nested
+}
+
+// summary returns the type, nesting mode, constraints and flags of the
+// attribute followed by its description, like the list items of Render, for
+// example "(Block List, Min: 1, Max: 3) The rules.".
+func (a *Attribute) summary(opts Options) string {
+	flags := []string{a.Type}
+	if a.NestingMode == string(tfjson.SchemaNestingModeSingle) {
+		if a.IsBlock {
+			switch {
+			case a.Required:
+				flags = append(flags, "Required")
+			case a.Optional:
+				flags = append(flags, "Optional")
+			case a.ReadOnly:
+				flags = append(flags, "Read-Only")
+			}
+		}
+	} else if a.MinItems > 0 {
+		flags = append(flags, fmt.Sprintf("Min: %d", a.MinItems))
+	}
+	if a.MaxItems > 0 {
+		flags = append(flags, fmt.Sprintf("Max: %d", a.MaxItems))
+	}
+	if a.Sensitive {
+		flags = append(flags, "Sensitive")
+	}
+	if a.Deprecated {
+		flags = append(flags, "Deprecated")
+	}
+
+	summary := "(" + strings.Join(flags, ", ") + ")"
+	if desc := formatDescription(a.Description, tfjson.SchemaDescriptionKind(a.DescriptionKind), "", opts); desc != "" {
+		summary += " " + desc
+	}
+	return summary
+}
+
+func nestingTypeName(prefix string, mode tfjson.SchemaNestingMode) string {
+	switch mode {
+	case tfjson.SchemaNestingModeList:
+		return prefix + " List"
+	case tfjson.SchemaNestingModeSet:
+		return prefix + " Set"
+	case tfjson.SchemaNestingModeMap:
+		return prefix + " Map"
+	}
+	return prefix
+}
+
+func appendPath(parents []string, name string) []string {
+	path := make([]string, len(parents), len(parents)+1)
+	copy(path, parents)
+	return append(path, name)
+}
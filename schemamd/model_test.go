@@ -0,0 +1,95 @@
+package schemamd_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+
+	"github.com/hashicorp/terraform-plugin-docs/schemamd"
+)
+
+func TestNewBlock(t *testing.T) {
+	input, err := os.ReadFile("testdata/aws_acm_certificate.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var schema tfjson.Schema
+	err = json.Unmarshal(input, &schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block, err := schemamd.NewBlock(schema.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"optional certificate_authority_arn (String)",
+		"optional certificate_body (String)",
+		"optional certificate_chain (String)",
+		"optional domain_name (String)",
+		"optional id (String): The ID of this resource.",
+		"optional options (Block List) #nestedblock--options block list 0..1",
+		"optional options.certificate_transparency_logging_preference (String)",
+		"optional private_key (String) sensitive",
+		"optional subject_alternative_names (Set of String)",
+		"optional tags (Map of String)",
+		"optional tags_all (Map of String)",
+		"optional validation_method (String)",
+		"read-only arn (String)",
+		"read-only domain_validation_options (Set of Object) #nestedatt--domain_validation_options",
+		"read-only domain_validation_options.domain_name (String)",
+		"read-only domain_validation_options.resource_record_name (String)",
+		"read-only domain_validation_options.resource_record_type (String)",
+		"read-only domain_validation_options.resource_record_value (String)",
+		"read-only status (String)",
+		"read-only validation_emails (List of String)",
+	}
+
+	actual := []string{}
+	var walk func(b *schemamd.Block)
+	walk = func(b *schemamd.Block) {
+		for _, att := range b.Attributes() {
+			group := "required"
+			switch {
+			case att.Optional:
+				group = "optional"
+			case att.ReadOnly:
+				group = "read-only"
+			}
+
+			s := fmt.Sprintf("%s %s (%s)", group, att.Path, att.Type)
+			if att.AnchorID != "" {
+				s += " #" + att.AnchorID
+			}
+			if att.IsBlock {
+				s += fmt.Sprintf(" block %s %d..%d", att.NestingMode, att.MinItems, att.MaxItems)
+			}
+			if att.Sensitive {
+				s += " sensitive"
+			}
+			if att.Deprecated {
+				s += " deprecated"
+			}
+			if att.Name == "id" {
+				s += ": " + att.Description
+			}
+			actual = append(actual, s)
+
+			if att.Nested != nil {
+				walk(att.Nested)
+			}
+		}
+	}
+	walk(block)
+
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Fatalf("Unexpected diff (-wanted, +got): %s", diff)
+	}
+}
@@ -0,0 +1,236 @@
+package schemamd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// Style is the layout of the rendered schema.
+type Style string
+
+const (
+	// StyleDefault renders a "## Schema" section with the attributes and
+	// nested blocks grouped as Required, Optional and Read-Only.
+	StyleDefault Style = "default"
+
+	// StyleClassic renders "## Argument Reference" and "## Attributes
+	// Reference" sections, as in hand-written provider docs.
+	StyleClassic Style = "classic"
+
+	// StyleTable renders like StyleDefault, with each group of attributes
+	// and nested blocks written as a table of name, type, flags and
+	// description.
+	StyleTable Style = "table"
+)
+
+var styles = []Style{StyleDefault, StyleClassic, StyleTable}
+
+// ParseStyle returns the style with the given name, StyleDefault for an empty
+// name.
+func ParseStyle(name string) (Style, error) {
+	if name == "" {
+		return StyleDefault, nil
+	}
+
+	for _, s := range styles {
+		if string(s) == name {
+			return s, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown schema style %q, expected one of %q", name, styles)
+}
+
+// Group is one of the groups attributes and nested blocks are sorted in.
+type Group string
+
+const (
+	// GroupRequired holds the required attributes and nested blocks.
+	GroupRequired Group = "required"
+
+	// GroupOptional holds the optional attributes and nested blocks,
+	// including optional and computed ones.
+	GroupOptional Group = "optional"
+
+	// GroupReadOnly holds the computed attributes and nested blocks that
+	// cannot be set in configuration.
+	GroupReadOnly Group = "read-only"
+)
+
+// ParseGroup returns the group with the given name.
+func ParseGroup(name string) (Group, error) {
+	for _, gf := range groupFilters {
+		if string(gf.group) == name {
+			return gf.group, nil
+		}
+	}
+
+	names := []Group{}
+	for _, gf := range groupFilters {
+		names = append(names, gf.group)
+	}
+	return "", fmt.Errorf("unknown schema group %q, expected one of %q", name, names)
+}
+
+// GroupTitle holds the titles a group is written with.
+type GroupTitle struct {
+	// Title is the text of the group heading in the root block, for example
+	// "Required".
+	Title string
+
+	// NestedTitle is written before the group in nested schemas, for example
+	// "Required:".
+	NestedTitle string
+}
+
+// Options configures RenderWithOptions. The zero value renders like Render.
+type Options struct {
+	// Style is the layout of the rendered schema, StyleDefault if empty.
+	Style Style
+
+	// InlineObjectMaxAttributes writes object types with at most this many
+	// attributes inline in the type, for example
+	// "List of Object{name: String, port: Number}", instead of in a nested
+	// schema section. Object types nested in their attributes must be
+	// inlined too. Zero disables inlining.
+	InlineObjectMaxAttributes int
+
+	// HeadingLevel is the level of the top-level headings, such as
+	// "## Schema", 2 if zero. Group titles of the root block and nested
+	// schema headings are one level deeper.
+	HeadingLevel int
+
+	// Groups are the groups written, in order, all groups in the default
+	// order if empty. Groups that are not listed are left out, for example
+	// GroupReadOnly on data source pages that only document arguments.
+	Groups []Group
+
+	// GroupTitles overrides the titles of groups in the default and table
+	// styles, for example to translate them. Empty titles keep the default.
+	GroupTitles map[Group]GroupTitle
+
+	// EscapePlainDescriptions escapes the Markdown characters of descriptions
+	// of the plain kind, such as "*" and "_", so they are rendered as written.
+	// Descriptions without a kind are never escaped.
+	EscapePlainDescriptions bool
+
+	// NestedSummaries writes the type, flags and description of the
+	// attribute or block below each nested schema heading, for example
+	// "(Block List, Min: 1) The rules.", repeating its list item or row.
+	NestedSummaries bool
+}
+
+// maxHeadingLevel is the deepest Markdown heading level, the top-level
+// headings must leave room for the nested ones.
+const maxHeadingLevel = 6
+
+// Validate returns an error if the options are invalid.
+func (opts Options) Validate() error {
+	_, err := ParseStyle(string(opts.Style))
+	if err != nil {
+		return err
+	}
+
+	if opts.InlineObjectMaxAttributes < 0 {
+		return fmt.Errorf("inline object max attributes must not be negative")
+	}
+
+	if opts.HeadingLevel < 0 || opts.HeadingLevel > maxHeadingLevel-1 {
+		return fmt.Errorf("heading level must be between 0 (default) and %d", maxHeadingLevel-1)
+	}
+
+	seen := map[Group]bool{}
+	for _, g := range opts.Groups {
+		_, err = ParseGroup(string(g))
+		if err != nil {
+			return err
+		}
+		if seen[g] {
+			return fmt.Errorf("duplicate schema group %q", g)
+		}
+		seen[g] = true
+	}
+
+	for g := range opts.GroupTitles {
+		_, err = ParseGroup(string(g))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// heading returns a heading depth levels below the top-level headings.
+func (opts Options) heading(depth int, text string) string {
+	level := opts.HeadingLevel
+	if level == 0 {
+		level = 2
+	}
+	return strings.Repeat("#", level+depth) + " " + text
+}
+
+// groupIndexes returns the indexes in groupFilters of the groups to write, in
+// order.
+func (opts Options) groupIndexes() []int {
+	indexes := []int{}
+	if len(opts.Groups) == 0 {
+		for i := range groupFilters {
+			indexes = append(indexes, i)
+		}
+		return indexes
+	}
+
+	for _, g := range opts.Groups {
+		for i, gf := range groupFilters {
+			if gf.group == g {
+				indexes = append(indexes, i)
+			}
+		}
+	}
+	return indexes
+}
+
+// groupTitle returns the titles of the group with index i of groupFilters.
+func (opts Options) groupTitle(i int) GroupTitle {
+	title := groupFilters[i].title
+
+	override := opts.GroupTitles[groupFilters[i].group]
+	if override.Title != "" {
+		title.Title = override.Title
+	}
+	if override.NestedTitle != "" {
+		title.NestedTitle = override.NestedTitle
+	}
+
+	return title
+}
+
+// RenderWithOptions writes a Markdown formatted Schema definition to the
+// specified writer, laid out according to the options.
+func RenderWithOptions(schema *tfjson.Schema, w io.Writer, opts Options) error {
+	err := opts.Validate()
+	if err != nil {
+		return err
+	}
+
+	switch opts.Style {
+	case StyleClassic:
+		err = renderClassic(schema, w, opts)
+		if err != nil {
+			return fmt.Errorf("unable to render schema: %w", err)
+		}
+		return nil
+	case StyleTable:
+		err = renderTable(schema, w, opts)
+		if err != nil {
+			return fmt.Errorf("unable to render schema: %w", err)
+		}
+		return nil
+	default:
+		return renderDefault(schema, w, opts)
+	}
+}
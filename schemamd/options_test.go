@@ -0,0 +1,81 @@
+package schemamd_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-docs/schemamd"
+)
+
+func TestOptionsValidate(t *testing.T) {
+	for _, c := range []struct {
+		name        string
+		options     schemamd.Options
+		expectedErr string
+	}{
+		{
+			"zero value",
+			schemamd.Options{},
+			"",
+		},
+		{
+			"all options",
+			schemamd.Options{
+				Style:                     schemamd.StyleTable,
+				InlineObjectMaxAttributes: 2,
+				HeadingLevel:              5,
+				Groups:                    []schemamd.Group{schemamd.GroupOptional, schemamd.GroupRequired},
+				GroupTitles: map[schemamd.Group]schemamd.GroupTitle{
+					schemamd.GroupReadOnly: {Title: "Nur lesbar"},
+				},
+			},
+			"",
+		},
+		{
+			"unknown style",
+			schemamd.Options{Style: "fancy"},
+			`unknown schema style "fancy", expected one of ["default" "classic" "table"]`,
+		},
+		{
+			"negative inline object max attributes",
+			schemamd.Options{InlineObjectMaxAttributes: -1},
+			"inline object max attributes must not be negative",
+		},
+		{
+			"negative heading level",
+			schemamd.Options{HeadingLevel: -1},
+			"heading level must be between 0 (default) and 5",
+		},
+		{
+			"heading level too deep",
+			schemamd.Options{HeadingLevel: 6},
+			"heading level must be between 0 (default) and 5",
+		},
+		{
+			"unknown group",
+			schemamd.Options{Groups: []schemamd.Group{"computed"}},
+			`unknown schema group "computed", expected one of ["required" "optional" "read-only"]`,
+		},
+		{
+			"duplicate group",
+			schemamd.Options{Groups: []schemamd.Group{schemamd.GroupRequired, schemamd.GroupRequired}},
+			`duplicate schema group "required"`,
+		},
+		{
+			"unknown group title",
+			schemamd.Options{GroupTitles: map[schemamd.Group]schemamd.GroupTitle{"computed": {Title: "Computed"}}},
+			`unknown schema group "computed", expected one of ["required" "optional" "read-only"]`,
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			err := c.options.Validate()
+			switch {
+			case c.expectedErr == "" && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case c.expectedErr != "" && err == nil:
+				t.Fatalf("expected error %q, got none", c.expectedErr)
+			case c.expectedErr != "" && err.Error() != c.expectedErr:
+				t.Fatalf("expected error %q, got %q", c.expectedErr, err)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strconv"
 	"strings"
 
 	tfjson "github.com/hashicorp/terraform-json"
@@ -18,12 +19,26 @@ import (
 // 	 "version": 0
 // },
 func Render(schema *tfjson.Schema, w io.Writer) error {
-	_, err := io.WriteString(w, "## Schema\n\n")
+	return renderDefault(schema, w, Options{})
+}
+
+func renderDefault(schema *tfjson.Schema, w io.Writer, opts Options) error {
+	_, err := io.WriteString(w, opts.heading(0, "Schema")+"\n\n")
 	if err != nil {
 		return err
 	}
 
-	err = writeRootBlock(w, schema.Block)
+	block, err := NewBlockWithOptions(schema.Block, opts)
+	if err != nil {
+		return fmt.Errorf("unable to render schema: %w", err)
+	}
+
+	err = writeDeprecatedSummary(w, block, opts)
+	if err != nil {
+		return err
+	}
+
+	err = writeRootBlock(w, schema.Block, opts)
 	if err != nil {
 		return fmt.Errorf("unable to render schema: %w", err)
 	}
@@ -33,8 +48,8 @@ func Render(schema *tfjson.Schema, w io.Writer) error {
 
 // Group by Attribute/Block characteristics.
 type groupFilter struct {
-	topLevelTitle string
-	nestedTitle   string
+	group Group
+	title GroupTitle
 
 	filterAttribute func(att *tfjson.SchemaAttribute) bool
 	filterBlock     func(block *tfjson.SchemaBlockType) bool
@@ -46,9 +61,9 @@ var (
 	// * Optional
 	// * Read-Only
 	groupFilters = []groupFilter{
-		{"### Required", "Required:", childAttributeIsRequired, childBlockIsRequired},
-		{"### Optional", "Optional:", childAttributeIsOptional, childBlockIsOptional},
-		{"### Read-Only", "Read-Only:", childAttributeIsReadOnly, childBlockIsReadOnly},
+		{GroupRequired, GroupTitle{"Required", "Required:"}, childAttributeIsRequired, childBlockIsRequired},
+		{GroupOptional, GroupTitle{"Optional", "Optional:"}, childAttributeIsOptional, childBlockIsOptional},
+		{GroupReadOnly, GroupTitle{"Read-Only", "Read-Only:"}, childAttributeIsReadOnly, childBlockIsReadOnly},
 	}
 )
 
@@ -59,10 +74,15 @@ type nestedType struct {
 	object   *cty.Type
 	attrs    *tfjson.SchemaNestedAttributeType
 
-	group groupFilter
+	// summary is the type, nesting mode, constraints and description of the
+	// nested type, written below its heading.
+	summary string
+
+	// group is the index in groupFilters of the group of the nested type
+	group int
 }
 
-func writeAttribute(w io.Writer, path []string, att *tfjson.SchemaAttribute, group groupFilter) ([]nestedType, error) {
+func writeAttribute(w io.Writer, path []string, att *tfjson.SchemaAttribute, group int, opts Options) ([]nestedType, error) {
 	name := path[len(path)-1]
 
 	_, err := io.WriteString(w, "- `"+name+"` ")
@@ -71,19 +91,26 @@ func writeAttribute(w io.Writer, path []string, att *tfjson.SchemaAttribute, gro
 	}
 
 	if name == "id" && att.Description == "" {
-		att.Description = "The ID of this resource."
+		// the schema may be rendered concurrently, so the default description
+		// is set on a copy
+		withDescription := *att
+		withDescription.Description = "The ID of this resource."
+		att = &withDescription
 	}
 
+	var summary strings.Builder
 	if att.AttributeNestedType == nil {
-		err = WriteAttributeDescription(w, att, false)
+		err = writeAttributeDescription(&summary, att, false, opts)
 	} else {
-		err = WriteNestedAttributeTypeDescription(w, att, false)
+		err = writeNestedAttributeTypeDescription(&summary, att, false, opts)
 	}
 	if err != nil {
 		return nil, err
 	}
-	if att.AttributeType.IsTupleType() {
-		return nil, fmt.Errorf("TODO: tuples are not yet supported")
+
+	_, err = io.WriteString(w, summary.String())
+	if err != nil {
+		return nil, err
 	}
 
 	anchorID := "nestedatt--" + strings.Join(path, "--")
@@ -99,10 +126,11 @@ func writeAttribute(w io.Writer, path []string, att *tfjson.SchemaAttribute, gro
 			anchorID: anchorID,
 			path:     path,
 			attrs:    att.AttributeNestedType,
+			summary:  summary.String(),
 
 			group: group,
 		})
-	case att.AttributeType.IsObjectType():
+	case att.AttributeType.IsObjectType() && !inlineObject(att.AttributeType, opts):
 		_, err = io.WriteString(w, " (see [below for nested schema](#"+anchorID+"))")
 		if err != nil {
 			return nil, err
@@ -112,10 +140,11 @@ func writeAttribute(w io.Writer, path []string, att *tfjson.SchemaAttribute, gro
 			anchorID: anchorID,
 			path:     path,
 			object:   &att.AttributeType,
+			summary:  summary.String(),
 
 			group: group,
 		})
-	case att.AttributeType.IsCollectionType() && att.AttributeType.ElementType().IsObjectType():
+	case att.AttributeType.IsCollectionType() && att.AttributeType.ElementType().IsObjectType() && !inlineObject(att.AttributeType.ElementType(), opts):
 		_, err = io.WriteString(w, " (see [below for nested schema](#"+anchorID+"))")
 		if err != nil {
 			return nil, err
@@ -126,9 +155,20 @@ func writeAttribute(w io.Writer, path []string, att *tfjson.SchemaAttribute, gro
 			anchorID: anchorID,
 			path:     path,
 			object:   &nt,
+			summary:  summary.String(),
 
 			group: group,
 		})
+	case att.AttributeType.IsTupleType():
+		nestedTypes, err = tupleNestedTypes("nestedatt--", path, att.AttributeType, group, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		err = writeTupleNestedTypeLinks(w, nestedTypes)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	_, err = io.WriteString(w, "\n")
@@ -139,7 +179,51 @@ func writeAttribute(w io.Writer, path []string, att *tfjson.SchemaAttribute, gro
 	return nestedTypes, nil
 }
 
-func writeBlockType(w io.Writer, path []string, block *tfjson.SchemaBlockType) ([]nestedType, error) {
+// tupleNestedTypes returns the nested types of the elements of a tuple type
+// that are object types or collections of object types, the path of each
+// ends with the index of the element.
+func tupleNestedTypes(anchorPrefix string, path []string, ty cty.Type, group int, opts Options) ([]nestedType, error) {
+	nestedTypes := []nestedType{}
+	for i, et := range ty.TupleElementTypes() {
+		object, ok := nestedObjectType(et, opts)
+		if !ok {
+			continue
+		}
+
+		var summary strings.Builder
+		err := WriteTypeWithOptions(&summary, et, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		elemPath := appendPath(path, strconv.Itoa(i))
+		nestedTypes = append(nestedTypes, nestedType{
+			anchorID: anchorPrefix + strings.Join(elemPath, "--"),
+			path:     elemPath,
+			object:   &object,
+			summary:  "(" + summary.String() + ")",
+
+			group: group,
+		})
+	}
+	return nestedTypes, nil
+}
+
+func writeTupleNestedTypeLinks(w io.Writer, nestedTypes []nestedType) error {
+	if len(nestedTypes) == 0 {
+		return nil
+	}
+
+	links := []string{}
+	for _, nt := range nestedTypes {
+		links = append(links, "[`"+strings.Join(nt.path, ".")+"`](#"+nt.anchorID+")")
+	}
+
+	_, err := io.WriteString(w, " (see below for nested schema of "+strings.Join(links, ", ")+")")
+	return err
+}
+
+func writeBlockType(w io.Writer, path []string, block *tfjson.SchemaBlockType, opts Options) ([]nestedType, error) {
 	name := path[len(path)-1]
 
 	_, err := io.WriteString(w, "- `"+name+"` ")
@@ -147,16 +231,23 @@ func writeBlockType(w io.Writer, path []string, block *tfjson.SchemaBlockType) (
 		return nil, err
 	}
 
-	err = WriteBlockTypeDescription(w, block)
+	var summary strings.Builder
+	err = writeBlockTypeDescription(&summary, block, opts)
 	if err != nil {
 		return nil, fmt.Errorf("unable to write block description for %q: %w", name, err)
 	}
 
+	_, err = io.WriteString(w, summary.String())
+	if err != nil {
+		return nil, err
+	}
+
 	anchorID := "nestedblock--" + strings.Join(path, "--")
 	nt := nestedType{
 		anchorID: anchorID,
 		path:     path,
 		block:    block.Block,
+		summary:  summary.String(),
 	}
 
 	_, err = io.WriteString(w, " (see [below for nested schema](#"+anchorID+"))")
@@ -172,8 +263,8 @@ func writeBlockType(w io.Writer, path []string, block *tfjson.SchemaBlockType) (
 	return []nestedType{nt}, nil
 }
 
-func writeRootBlock(w io.Writer, block *tfjson.SchemaBlock) error {
-	return writeBlockChildren(w, nil, block, true)
+func writeRootBlock(w io.Writer, block *tfjson.SchemaBlock, opts Options) error {
+	return writeBlockChildren(w, nil, block, true, opts)
 }
 
 // A Block contains:
@@ -202,39 +293,10 @@ func writeRootBlock(w io.Writer, block *tfjson.SchemaBlock) error {
 // 	 },
 // 	 "description_kind": "plain"
 // },
-func writeBlockChildren(w io.Writer, parents []string, block *tfjson.SchemaBlock, root bool) error {
-	names := []string{}
-	for n := range block.Attributes {
-		names = append(names, n)
-	}
-	for n := range block.NestedBlocks {
-		names = append(names, n)
-	}
-
-	groups := map[int][]string{}
-
-	// Group Attributes/Blocks by characteristics.
-nameLoop:
-	for _, n := range names {
-		if childBlock, ok := block.NestedBlocks[n]; ok {
-			for i, gf := range groupFilters {
-				if gf.filterBlock(childBlock) {
-					groups[i] = append(groups[i], n)
-					continue nameLoop
-				}
-			}
-		} else if childAtt, ok := block.Attributes[n]; ok {
-			for i, gf := range groupFilters {
-				if gf.filterAttribute(childAtt) {
-					groups[i] = append(groups[i], n)
-					continue nameLoop
-				}
-			}
-		}
-
-		return fmt.Errorf("no match for %q, this can happen if you have incompatible schema defined, for example an "+
-			"optional block where all the child attributes are computed, in which case the block itself should also "+
-			"be marked computed", n)
+func writeBlockChildren(w io.Writer, parents []string, block *tfjson.SchemaBlock, root bool, opts Options) error {
+	groups, err := groupBlockChildren(block)
+	if err != nil {
+		return err
 	}
 
 	nestedTypes := []nestedType{}
@@ -268,16 +330,15 @@ nameLoop:
 	//       Recursively do nested type functionality
 	//   End
 	// End
-	for i, gf := range groupFilters {
+	for _, i := range opts.groupIndexes() {
 		sortedNames := groups[i]
 		if len(sortedNames) == 0 {
 			continue
 		}
-		sort.Strings(sortedNames)
 
-		groupTitle := gf.topLevelTitle
-		if !root {
-			groupTitle = gf.nestedTitle
+		groupTitle := opts.groupTitle(i).NestedTitle
+		if root {
+			groupTitle = opts.heading(1, opts.groupTitle(i).Title)
 		}
 
 		_, err := io.WriteString(w, groupTitle+"\n\n")
@@ -289,7 +350,7 @@ nameLoop:
 			path := append(parents, name)
 
 			if childBlock, ok := block.NestedBlocks[name]; ok {
-				nt, err := writeBlockType(w, path, childBlock)
+				nt, err := writeBlockType(w, path, childBlock, opts)
 				if err != nil {
 					return fmt.Errorf("unable to render block %q: %w", name, err)
 				}
@@ -297,7 +358,7 @@ nameLoop:
 				nestedTypes = append(nestedTypes, nt...)
 				continue
 			} else if childAtt, ok := block.Attributes[name]; ok {
-				nt, err := writeAttribute(w, path, childAtt, gf)
+				nt, err := writeAttribute(w, path, childAtt, i, opts)
 				if err != nil {
 					return fmt.Errorf("unable to render attribute %q: %w", name, err)
 				}
@@ -315,7 +376,7 @@ nameLoop:
 		}
 	}
 
-	err := writeNestedTypes(w, nestedTypes)
+	err = writeNestedTypes(w, nestedTypes, opts)
 	if err != nil {
 		return err
 	}
@@ -323,31 +384,79 @@ nameLoop:
 	return nil
 }
 
-func writeNestedTypes(w io.Writer, nestedTypes []nestedType) error {
+// groupBlockChildren groups the names of the attributes and nested blocks of
+// a block by the index of their groupFilter, sorted by name.
+func groupBlockChildren(block *tfjson.SchemaBlock) (map[int][]string, error) {
+	names := []string{}
+	for n := range block.Attributes {
+		names = append(names, n)
+	}
+	for n := range block.NestedBlocks {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	groups := map[int][]string{}
+
+	// Group Attributes/Blocks by characteristics.
+nameLoop:
+	for _, n := range names {
+		if childBlock, ok := block.NestedBlocks[n]; ok {
+			for i, gf := range groupFilters {
+				if gf.filterBlock(childBlock) {
+					groups[i] = append(groups[i], n)
+					continue nameLoop
+				}
+			}
+		} else if childAtt, ok := block.Attributes[n]; ok {
+			for i, gf := range groupFilters {
+				if gf.filterAttribute(childAtt) {
+					groups[i] = append(groups[i], n)
+					continue nameLoop
+				}
+			}
+		}
+
+		return nil, fmt.Errorf("no match for %q, this can happen if you have incompatible schema defined, for example an "+
+			"optional block where all the child attributes are computed, in which case the block itself should also "+
+			"be marked computed", n)
+	}
+
+	return groups, nil
+}
+
+func writeNestedTypes(w io.Writer, nestedTypes []nestedType, opts Options) error {
 	for _, nt := range nestedTypes {
 		_, err := io.WriteString(w, "<a id=\""+nt.anchorID+"\"></a>\n")
 		if err != nil {
 			return err
 		}
 
-		_, err = io.WriteString(w, "### Nested Schema for `"+strings.Join(nt.path, ".")+"`\n\n")
+		_, err = io.WriteString(w, opts.heading(1, "Nested Schema for `"+strings.Join(nt.path, ".")+"`")+"\n\n")
 		if err != nil {
 			return err
 		}
 
+		if opts.NestedSummaries && nt.summary != "" {
+			_, err = io.WriteString(w, nt.summary+"\n\n")
+			if err != nil {
+				return err
+			}
+		}
+
 		switch {
 		case nt.block != nil:
-			err = writeBlockChildren(w, nt.path, nt.block, false)
+			err = writeBlockChildren(w, nt.path, nt.block, false, opts)
 			if err != nil {
 				return err
 			}
 		case nt.object != nil:
-			err = writeObjectChildren(w, nt.path, *nt.object, nt.group)
+			err = writeObjectChildren(w, nt.path, *nt.object, nt.group, opts)
 			if err != nil {
 				return err
 			}
 		case nt.attrs != nil:
-			err = writeNestedAttributeChildren(w, nt.path, nt.attrs, nt.group)
+			err = writeNestedAttributeChildren(w, nt.path, nt.attrs, nt.group, opts)
 			if err != nil {
 				return err
 			}
@@ -364,32 +473,25 @@ func writeNestedTypes(w io.Writer, nestedTypes []nestedType) error {
 	return nil
 }
 
-func writeObjectAttribute(w io.Writer, path []string, att cty.Type, group groupFilter) ([]nestedType, error) {
+func writeObjectAttribute(w io.Writer, path []string, att cty.Type, group int, opts Options) ([]nestedType, error) {
 	name := path[len(path)-1]
 
-	_, err := io.WriteString(w, "- `"+name+"` (")
-	if err != nil {
-		return nil, err
-	}
-
-	err = WriteType(w, att)
+	var ty strings.Builder
+	err := WriteTypeWithOptions(&ty, att, opts)
 	if err != nil {
 		return nil, err
 	}
+	summary := "(" + ty.String() + ")"
 
-	_, err = io.WriteString(w, ")")
+	_, err = io.WriteString(w, "- `"+name+"` "+summary)
 	if err != nil {
 		return nil, err
 	}
 
-	if att.IsTupleType() {
-		return nil, fmt.Errorf("TODO: tuples are not yet supported")
-	}
-
 	anchorID := "nestedobjatt--" + strings.Join(path, "--")
 	nestedTypes := []nestedType{}
 	switch {
-	case att.IsObjectType():
+	case att.IsObjectType() && !inlineObject(att, opts):
 		_, err = io.WriteString(w, " (see [below for nested schema](#"+anchorID+"))")
 		if err != nil {
 			return nil, err
@@ -399,10 +501,11 @@ func writeObjectAttribute(w io.Writer, path []string, att cty.Type, group groupF
 			anchorID: anchorID,
 			path:     path,
 			object:   &att,
+			summary:  summary,
 
 			group: group,
 		})
-	case att.IsCollectionType() && att.ElementType().IsObjectType():
+	case att.IsCollectionType() && att.ElementType().IsObjectType() && !inlineObject(att.ElementType(), opts):
 		_, err = io.WriteString(w, " (see [below for nested schema](#"+anchorID+"))")
 		if err != nil {
 			return nil, err
@@ -413,9 +516,20 @@ func writeObjectAttribute(w io.Writer, path []string, att cty.Type, group groupF
 			anchorID: anchorID,
 			path:     path,
 			object:   &nt,
+			summary:  summary,
 
 			group: group,
 		})
+	case att.IsTupleType():
+		nestedTypes, err = tupleNestedTypes("nestedobjatt--", path, att, group, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		err = writeTupleNestedTypeLinks(w, nestedTypes)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	_, err = io.WriteString(w, "\n")
@@ -426,8 +540,8 @@ func writeObjectAttribute(w io.Writer, path []string, att cty.Type, group groupF
 	return nestedTypes, nil
 }
 
-func writeObjectChildren(w io.Writer, parents []string, ty cty.Type, group groupFilter) error {
-	_, err := io.WriteString(w, group.nestedTitle+"\n\n")
+func writeObjectChildren(w io.Writer, parents []string, ty cty.Type, group int, opts Options) error {
+	_, err := io.WriteString(w, opts.groupTitle(group).NestedTitle+"\n\n")
 	if err != nil {
 		return err
 	}
@@ -444,7 +558,7 @@ func writeObjectChildren(w io.Writer, parents []string, ty cty.Type, group group
 		att := atts[name]
 		path := append(parents, name)
 
-		nt, err := writeObjectAttribute(w, path, att, group)
+		nt, err := writeObjectAttribute(w, path, att, group, opts)
 		if err != nil {
 			return fmt.Errorf("unable to render attribute %q: %w", name, err)
 		}
@@ -457,7 +571,7 @@ func writeObjectChildren(w io.Writer, parents []string, ty cty.Type, group group
 		return err
 	}
 
-	err = writeNestedTypes(w, nestedTypes)
+	err = writeNestedTypes(w, nestedTypes, opts)
 	if err != nil {
 		return err
 	}
@@ -465,8 +579,8 @@ func writeObjectChildren(w io.Writer, parents []string, ty cty.Type, group group
 	return nil
 }
 
-func writeNestedAttributeChildren(w io.Writer, parents []string, nestedAttributes *tfjson.SchemaNestedAttributeType, group groupFilter) error {
-	_, err := io.WriteString(w, group.nestedTitle+"\n\n")
+func writeNestedAttributeChildren(w io.Writer, parents []string, nestedAttributes *tfjson.SchemaNestedAttributeType, group int, opts Options) error {
+	_, err := io.WriteString(w, opts.groupTitle(group).NestedTitle+"\n\n")
 	if err != nil {
 		return err
 	}
@@ -482,7 +596,7 @@ func writeNestedAttributeChildren(w io.Writer, parents []string, nestedAttribute
 		att := nestedAttributes.Attributes[name]
 		path := append(parents, name)
 
-		nt, err := writeAttribute(w, path, att, group)
+		nt, err := writeAttribute(w, path, att, group, opts)
 		if err != nil {
 			return fmt.Errorf("unable to render attribute %q: %w", name, err)
 		}
@@ -495,7 +609,7 @@ func writeNestedAttributeChildren(w io.Writer, parents []string, nestedAttribute
 		return err
 	}
 
-	err = writeNestedTypes(w, nestedTypes)
+	err = writeNestedTypes(w, nestedTypes, opts)
 	if err != nil {
 		return err
 	}
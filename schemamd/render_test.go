@@ -16,26 +16,162 @@ func TestRender(t *testing.T) {
 		name         string
 		inputFile    string
 		expectedFile string
+		options      schemamd.Options
 	}{
 		{
 			"aws_route_table_association",
 			"testdata/aws_route_table_association.schema.json",
 			"testdata/aws_route_table_association.md",
+			schemamd.Options{},
 		},
 		{
 			"aws_acm_certificate",
 			"testdata/aws_acm_certificate.schema.json",
 			"testdata/aws_acm_certificate.md",
+			schemamd.Options{},
 		},
 		{
 			"awscc_logs_log_group",
 			"testdata/awscc_logs_log_group.schema.json",
 			"testdata/awscc_logs_log_group.md",
+			schemamd.Options{},
 		},
 		{
 			"awscc_acmpca_certificate",
 			"testdata/awscc_acmpca_certificate.schema.json",
 			"testdata/awscc_acmpca_certificate.md",
+			schemamd.Options{},
+		},
+		{
+			"aws_acm_certificate classic",
+			"testdata/aws_acm_certificate.schema.json",
+			"testdata/aws_acm_certificate.classic.md",
+			schemamd.Options{Style: schemamd.StyleClassic},
+		},
+		{
+			"awscc_acmpca_certificate classic",
+			"testdata/awscc_acmpca_certificate.schema.json",
+			"testdata/awscc_acmpca_certificate.classic.md",
+			schemamd.Options{Style: schemamd.StyleClassic},
+		},
+		{
+			"aws_acm_certificate table",
+			"testdata/aws_acm_certificate.schema.json",
+			"testdata/aws_acm_certificate.table.md",
+			schemamd.Options{Style: schemamd.StyleTable},
+		},
+		{
+			"awscc_acmpca_certificate table",
+			"testdata/awscc_acmpca_certificate.schema.json",
+			"testdata/awscc_acmpca_certificate.table.md",
+			schemamd.Options{Style: schemamd.StyleTable},
+		},
+		{
+			"awscc_acmpca_certificate nested summaries table",
+			"testdata/awscc_acmpca_certificate.schema.json",
+			"testdata/awscc_acmpca_certificate.summaries.table.md",
+			schemamd.Options{Style: schemamd.StyleTable, NestedSummaries: true},
+		},
+		{
+			"tuple",
+			"testdata/tuple.schema.json",
+			"testdata/tuple.md",
+			schemamd.Options{},
+		},
+		{
+			"tuple classic",
+			"testdata/tuple.schema.json",
+			"testdata/tuple.classic.md",
+			schemamd.Options{Style: schemamd.StyleClassic},
+		},
+		{
+			"tuple table",
+			"testdata/tuple.schema.json",
+			"testdata/tuple.table.md",
+			schemamd.Options{Style: schemamd.StyleTable},
+		},
+		{
+			"tuple inline objects",
+			"testdata/tuple.schema.json",
+			"testdata/tuple.inline.md",
+			schemamd.Options{InlineObjectMaxAttributes: 1},
+		},
+		{
+			"deprecated",
+			"testdata/deprecated.schema.json",
+			"testdata/deprecated.md",
+			schemamd.Options{},
+		},
+		{
+			"deprecated nested summaries",
+			"testdata/deprecated.schema.json",
+			"testdata/deprecated.summaries.md",
+			schemamd.Options{NestedSummaries: true},
+		},
+		{
+			"deprecated classic",
+			"testdata/deprecated.schema.json",
+			"testdata/deprecated.classic.md",
+			schemamd.Options{Style: schemamd.StyleClassic},
+		},
+		{
+			"deprecated nested summaries classic",
+			"testdata/deprecated.schema.json",
+			"testdata/deprecated.summaries.classic.md",
+			schemamd.Options{Style: schemamd.StyleClassic, NestedSummaries: true},
+		},
+		{
+			"deprecated table",
+			"testdata/deprecated.schema.json",
+			"testdata/deprecated.table.md",
+			schemamd.Options{Style: schemamd.StyleTable},
+		},
+		{
+			"awscc_acmpca_certificate groups",
+			"testdata/awscc_acmpca_certificate.schema.json",
+			"testdata/awscc_acmpca_certificate.groups.md",
+			schemamd.Options{
+				HeadingLevel: 3,
+				Groups:       []schemamd.Group{schemamd.GroupOptional, schemamd.GroupRequired},
+				GroupTitles: map[schemamd.Group]schemamd.GroupTitle{
+					schemamd.GroupRequired: {Title: "Erforderlich", NestedTitle: "Erforderlich:"},
+					schemamd.GroupOptional: {Title: "Optional (Standardwerte)"},
+				},
+			},
+		},
+		{
+			"aws_acm_certificate without read-only",
+			"testdata/aws_acm_certificate.schema.json",
+			"testdata/aws_acm_certificate.arguments.md",
+			schemamd.Options{Groups: []schemamd.Group{schemamd.GroupRequired, schemamd.GroupOptional}},
+		},
+		{
+			"aws_acm_certificate without read-only classic",
+			"testdata/aws_acm_certificate.schema.json",
+			"testdata/aws_acm_certificate.arguments.classic.md",
+			schemamd.Options{Style: schemamd.StyleClassic, Groups: []schemamd.Group{schemamd.GroupRequired, schemamd.GroupOptional}},
+		},
+		{
+			"description kinds",
+			"testdata/description_kinds.schema.json",
+			"testdata/description_kinds.md",
+			schemamd.Options{},
+		},
+		{
+			"description kinds escaped",
+			"testdata/description_kinds.schema.json",
+			"testdata/description_kinds.escaped.md",
+			schemamd.Options{EscapePlainDescriptions: true},
+		},
+		{
+			"awscc_acmpca_certificate groups table",
+			"testdata/awscc_acmpca_certificate.schema.json",
+			"testdata/awscc_acmpca_certificate.groups.table.md",
+			schemamd.Options{
+				Style:        schemamd.StyleTable,
+				HeadingLevel: 1,
+				Groups:       []schemamd.Group{schemamd.GroupReadOnly, schemamd.GroupRequired},
+			},
 		},
 	} {
 		t.Run(c.name, func(t *testing.T) {
@@ -57,7 +193,7 @@ func TestRender(t *testing.T) {
 			}
 
 			b := &strings.Builder{}
-			err = schemamd.Render(&schema, b)
+			err = schemamd.RenderWithOptions(&schema, b, c.options)
 			if err != nil {
 				t.Fatal(err)
 			}
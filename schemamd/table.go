@@ -0,0 +1,138 @@
+package schemamd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+)
+
+// renderTable writes the schema grouped like Render, with each group of
+// attributes and nested blocks written as a Markdown table.
+func renderTable(schema *tfjson.Schema, w io.Writer, opts Options) error {
+	_, err := io.WriteString(w, opts.heading(0, "Schema")+"\n\n")
+	if err != nil {
+		return err
+	}
+
+	block, err := NewBlockWithOptions(schema.Block, opts)
+	if err != nil {
+		return err
+	}
+
+	err = writeDeprecatedSummary(w, block, opts)
+	if err != nil {
+		return err
+	}
+
+	return writeTableBlock(w, block, true, opts)
+}
+
+func writeTableBlock(w io.Writer, b *Block, root bool, opts Options) error {
+	for _, i := range b.groupIndexes() {
+		group := b.group(i)
+		if len(group) == 0 {
+			continue
+		}
+
+		title := opts.groupTitle(i).NestedTitle
+		if root {
+			title = opts.heading(1, opts.groupTitle(i).Title)
+		}
+
+		_, err := io.WriteString(w, title+"\n\n")
+		if err != nil {
+			return err
+		}
+
+		_, err = io.WriteString(w, "| Name | Type | Flags | Description |\n|------|------|-------|-------------|\n")
+		if err != nil {
+			return err
+		}
+
+		for _, att := range group {
+			err = writeTableRow(w, att, opts)
+			if err != nil {
+				return fmt.Errorf("unable to render %q: %w", att.Path, err)
+			}
+		}
+
+		_, err = io.WriteString(w, "\n")
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, att := range b.Attributes() {
+		for _, n := range att.nestedSchemas() {
+			_, err := io.WriteString(w, "<a id=\""+n.AnchorID+"\"></a>\n")
+			if err != nil {
+				return err
+			}
+
+			_, err = io.WriteString(w, opts.heading(1, "Nested Schema for `"+n.Path+"`")+"\n\n")
+			if err != nil {
+				return err
+			}
+
+			if opts.NestedSummaries {
+				_, err = io.WriteString(w, n.summary(opts)+"\n\n")
+				if err != nil {
+					return err
+				}
+			}
+
+			err = writeTableBlock(w, n.Nested, false, opts)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func writeTableRow(w io.Writer, att *Attribute, opts Options) error {
+	name := "`" + att.Name + "`"
+	if att.Nested != nil {
+		name = "[" + name + "](#" + att.AnchorID + ")"
+	} else if elems := att.nestedSchemas(); len(elems) > 0 {
+		links := []string{}
+		for _, elem := range elems {
+			links = append(links, "["+elem.Name+"](#"+elem.AnchorID+")")
+		}
+		name += " (" + strings.Join(links, ", ") + ")"
+	}
+
+	flags := []string{}
+	if att.Sensitive {
+		flags = append(flags, "Sensitive")
+	}
+	if att.Deprecated {
+		flags = append(flags, "Deprecated")
+	}
+	if att.MinItems > 0 {
+		flags = append(flags, fmt.Sprintf("Min: %d", att.MinItems))
+	}
+	if att.MaxItems > 0 {
+		flags = append(flags, fmt.Sprintf("Max: %d", att.MaxItems))
+	}
+
+	cells := []string{
+		name,
+		att.Type,
+		strings.Join(flags, ", "),
+		tableCell(formatDescription(att.Description, tfjson.SchemaDescriptionKind(att.DescriptionKind), "", opts)),
+	}
+
+	_, err := io.WriteString(w, "| "+strings.Join(cells, " | ")+" |\n")
+	return err
+}
+
+// tableCell escapes text for a single table cell, pipes would end the cell and
+// line breaks the row.
+func tableCell(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
@@ -3,18 +3,21 @@ package schemamd
 import (
 	"fmt"
 	"io"
-	"strings"
 
 	tfjson "github.com/hashicorp/terraform-json"
 )
 
 func WriteAttributeDescription(w io.Writer, att *tfjson.SchemaAttribute, includeRW bool) error {
+	return writeAttributeDescription(w, att, includeRW, Options{})
+}
+
+func writeAttributeDescription(w io.Writer, att *tfjson.SchemaAttribute, includeRW bool, opts Options) error {
 	_, err := io.WriteString(w, "(")
 	if err != nil {
 		return err
 	}
 
-	err = WriteType(w, att.AttributeType)
+	err = WriteTypeWithOptions(w, att.AttributeType, opts)
 	if err != nil {
 		return err
 	}
@@ -60,7 +63,7 @@ func WriteAttributeDescription(w io.Writer, att *tfjson.SchemaAttribute, include
 		return err
 	}
 
-	desc := strings.TrimSpace(att.Description)
+	desc := formatDescription(att.Description, att.DescriptionKind, "  ", opts)
 	if desc != "" {
 		_, err = io.WriteString(w, " "+desc)
 		if err != nil {
@@ -148,6 +148,44 @@ func TestWriteAttributeDescription(t *testing.T) {
 				Description:   "\n\t This is an attribute.\n\t ",
 			},
 		},
+
+		// description kinds, plain descriptions are only escaped if enabled in
+		// the options
+		{
+			"(String, Required) Set to *, _ or <none>, see [docs] and `x`.",
+			&tfjson.SchemaAttribute{
+				AttributeType:   cty.String,
+				Required:        true,
+				Description:     "Set to *, _ or <none>, see [docs] and `x`.",
+				DescriptionKind: tfjson.SchemaDescriptionKindPlain,
+			},
+		},
+		{
+			"(String, Optional) Set to *, _ or <none>, see [docs] and `x`.",
+			&tfjson.SchemaAttribute{
+				AttributeType: cty.String,
+				Optional:      true,
+				Description:   "Set to *, _ or <none>, see [docs] and `x`.",
+			},
+		},
+		{
+			"(String, Required) Set to `*` or **none**, see [docs](https://example.com).",
+			&tfjson.SchemaAttribute{
+				AttributeType:   cty.String,
+				Required:        true,
+				Description:     "Set to `*` or **none**, see [docs](https://example.com).",
+				DescriptionKind: tfjson.SchemaDescriptionKindMarkdown,
+			},
+		},
+		{
+			"(String, Required) This is an attribute.\n\n  Possible values are:\n  - `a`\n  - `b`",
+			&tfjson.SchemaAttribute{
+				AttributeType:   cty.String,
+				Required:        true,
+				Description:     "This is an attribute.\r\n\r\nPossible values are:\n- `a`  \n- `b`",
+				DescriptionKind: tfjson.SchemaDescriptionKindMarkdown,
+			},
+		},
 	} {
 		t.Run(c.expected, func(t *testing.T) {
 			b := &strings.Builder{}
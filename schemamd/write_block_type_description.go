@@ -3,12 +3,15 @@ package schemamd
 import (
 	"fmt"
 	"io"
-	"strings"
 
 	tfjson "github.com/hashicorp/terraform-json"
 )
 
 func WriteBlockTypeDescription(w io.Writer, block *tfjson.SchemaBlockType) error {
+	return writeBlockTypeDescription(w, block, Options{})
+}
+
+func writeBlockTypeDescription(w io.Writer, block *tfjson.SchemaBlockType, opts Options) error {
 	_, err := io.WriteString(w, "(Block")
 	if err != nil {
 		return err
@@ -84,7 +87,7 @@ func WriteBlockTypeDescription(w io.Writer, block *tfjson.SchemaBlockType) error
 		return err
 	}
 
-	desc := strings.TrimSpace(block.Block.Description)
+	desc := formatDescription(block.Block.Description, block.Block.DescriptionKind, "  ", opts)
 	if desc != "" {
 		_, err = io.WriteString(w, " "+desc)
 		if err != nil {
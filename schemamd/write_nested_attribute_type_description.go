@@ -3,12 +3,15 @@ package schemamd
 import (
 	"fmt"
 	"io"
-	"strings"
 
 	tfjson "github.com/hashicorp/terraform-json"
 )
 
 func WriteNestedAttributeTypeDescription(w io.Writer, att *tfjson.SchemaAttribute, includeRW bool) error {
+	return writeNestedAttributeTypeDescription(w, att, includeRW, Options{})
+}
+
+func writeNestedAttributeTypeDescription(w io.Writer, att *tfjson.SchemaAttribute, includeRW bool, opts Options) error {
 	nestedAttributeType := att.AttributeNestedType
 	if nestedAttributeType == nil {
 		return fmt.Errorf("AttributeNestedType is nil")
@@ -99,7 +102,7 @@ func WriteNestedAttributeTypeDescription(w io.Writer, att *tfjson.SchemaAttribut
 		return err
 	}
 
-	desc := strings.TrimSpace(att.Description)
+	desc := formatDescription(att.Description, att.DescriptionKind, "  ", opts)
 	if desc != "" {
 		_, err = io.WriteString(w, " "+desc)
 		if err != nil {
@@ -3,11 +3,19 @@ package schemamd
 import (
 	"fmt"
 	"io"
+	"sort"
 
 	"github.com/zclconf/go-cty/cty"
 )
 
 func WriteType(w io.Writer, ty cty.Type) error {
+	return WriteTypeWithOptions(w, ty, Options{})
+}
+
+// WriteTypeWithOptions writes the type like WriteType, with object types that
+// can be inlined according to the options written with their attributes, for
+// example "List of Object{name: String, port: Number}".
+func WriteTypeWithOptions(w io.Writer, ty cty.Type, opts Options) error {
 	switch {
 	case ty == cty.DynamicPseudoType:
 		_, err := io.WriteString(w, "Dynamic")
@@ -45,18 +53,125 @@ func WriteType(w io.Writer, ty cty.Type) error {
 				return err
 			}
 		}
-		err := WriteType(w, ty.ElementType())
+		err := WriteTypeWithOptions(w, ty.ElementType(), opts)
 		if err != nil {
 			return fmt.Errorf("unable to write element type for %q: %w", ty.FriendlyName(), err)
 		}
 		return nil
 	case ty.IsTupleType():
-		// TODO: write additional type info?
 		_, err := io.WriteString(w, "Tuple")
+		if err != nil {
+			return err
+		}
+		elems := ty.TupleElementTypes()
+		if len(elems) == 0 {
+			return nil
+		}
+		_, err = io.WriteString(w, " of [")
+		if err != nil {
+			return err
+		}
+		for i, et := range elems {
+			if i > 0 {
+				_, err = io.WriteString(w, ", ")
+				if err != nil {
+					return err
+				}
+			}
+			err = WriteTypeWithOptions(w, et, opts)
+			if err != nil {
+				return fmt.Errorf("unable to write element %d type for %q: %w", i, ty.FriendlyName(), err)
+			}
+		}
+		_, err = io.WriteString(w, "]")
 		return err
 	case ty.IsObjectType():
 		_, err := io.WriteString(w, "Object")
+		if err != nil {
+			return err
+		}
+		if !inlineObject(ty, opts) {
+			return nil
+		}
+		_, err = io.WriteString(w, "{")
+		if err != nil {
+			return err
+		}
+		for i, name := range sortedAttributeNames(ty) {
+			if i > 0 {
+				_, err = io.WriteString(w, ", ")
+				if err != nil {
+					return err
+				}
+			}
+			_, err = io.WriteString(w, name+": ")
+			if err != nil {
+				return err
+			}
+			err = WriteTypeWithOptions(w, ty.AttributeType(name), opts)
+			if err != nil {
+				return fmt.Errorf("unable to write attribute %q type for %q: %w", name, ty.FriendlyName(), err)
+			}
+		}
+		_, err = io.WriteString(w, "}")
 		return err
 	}
 	return fmt.Errorf("unexpected type %q", ty.FriendlyName())
 }
+
+// inlineObject returns true if the object type is written inline: it has at
+// most opts.InlineObjectMaxAttributes attributes, and all object types in its
+// attribute types are inlined too.
+func inlineObject(ty cty.Type, opts Options) bool {
+	if opts.InlineObjectMaxAttributes <= 0 || len(ty.AttributeTypes()) > opts.InlineObjectMaxAttributes {
+		return false
+	}
+
+	for _, at := range ty.AttributeTypes() {
+		if !inlineType(at, opts) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// inlineType returns true if all object types in the type are inlined.
+func inlineType(ty cty.Type, opts Options) bool {
+	switch {
+	case ty.IsCollectionType():
+		return inlineType(ty.ElementType(), opts)
+	case ty.IsTupleType():
+		for _, et := range ty.TupleElementTypes() {
+			if !inlineType(et, opts) {
+				return false
+			}
+		}
+		return true
+	case ty.IsObjectType():
+		return inlineObject(ty, opts)
+	}
+	return true
+}
+
+// nestedObjectType returns the object type documented in a nested schema
+// section for an object type, or collection of object types, that is not
+// inlined.
+func nestedObjectType(ty cty.Type, opts Options) (cty.Type, bool) {
+	if ty.IsCollectionType() {
+		ty = ty.ElementType()
+	}
+	if !ty.IsObjectType() || inlineObject(ty, opts) {
+		return cty.NilType, false
+	}
+	return ty, true
+}
+
+func sortedAttributeNames(ty cty.Type) []string {
+	names := []string{}
+	for n := range ty.AttributeTypes() {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
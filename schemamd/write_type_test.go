@@ -38,7 +38,11 @@ func TestWriteType(t *testing.T) {
 		{"Set of Boolean", cty.Set(cty.Bool)},
 
 		{"Tuple", cty.EmptyTuple},
-		{"Tuple", cty.Tuple([]cty.Type{cty.Bool})},
+		{"Tuple of [Boolean]", cty.Tuple([]cty.Type{cty.Bool})},
+		{"Tuple of [String, Number]", cty.Tuple([]cty.Type{cty.String, cty.Number})},
+		{"Tuple of [List of String, Object]", cty.Tuple([]cty.Type{cty.List(cty.String), cty.Object(map[string]cty.Type{
+			"bool": cty.Bool,
+		})})},
 
 		{"List of Map of Set of Object", cty.List(cty.Map(cty.Set(cty.Object(map[string]cty.Type{
 			"bool": cty.Bool,
@@ -57,3 +61,46 @@ func TestWriteType(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteTypeWithOptions(t *testing.T) {
+	small := cty.Object(map[string]cty.Type{
+		"name": cty.String,
+		"port": cty.Number,
+	})
+	large := cty.Object(map[string]cty.Type{
+		"a": cty.String,
+		"b": cty.String,
+		"c": cty.String,
+	})
+
+	for _, c := range []struct {
+		expected string
+		ty       cty.Type
+		max      int
+	}{
+		{"Object", small, 0},
+		{"Object{name: String, port: Number}", small, 2},
+		{"List of Object{name: String, port: Number}", cty.List(small), 2},
+		{"Map of List of Object{name: String, port: Number}", cty.Map(cty.List(small)), 2},
+		{"Object{nested: Set of Object{name: String, port: Number}}", cty.Object(map[string]cty.Type{
+			"nested": cty.Set(small),
+		}), 2},
+		{"Tuple of [String, Object{name: String, port: Number}]", cty.Tuple([]cty.Type{cty.String, small}), 2},
+		{"List of Object", cty.List(large), 2},
+		{"Object", cty.Object(map[string]cty.Type{
+			"nested": large,
+		}), 2},
+	} {
+		t.Run(fmt.Sprintf("%s %d %s", c.ty.FriendlyName(), c.max, c.expected), func(t *testing.T) {
+			b := &strings.Builder{}
+			err := schemamd.WriteTypeWithOptions(b, c.ty, schemamd.Options{InlineObjectMaxAttributes: c.max})
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual := b.String()
+			if diff := cmp.Diff(c.expected, actual); diff != "" {
+				t.Fatalf("Unexpected diff (-wanted, +got): %s", diff)
+			}
+		})
+	}
+}